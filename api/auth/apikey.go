@@ -0,0 +1,186 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"oapi-to-rest/api/common"
+	"oapi-to-rest/pkg/errlib"
+	"oapi-to-rest/pkg/jwt"
+	"oapi-to-rest/pkg/middleware"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// marks api keys in logs and secret scanners
+	apiKeyPrefix = "ak_"
+
+	// characters of the key kept in plaintext to recognize it
+	apiKeyDisplayLength = 11
+)
+
+func (a *AuthImpl) GetApiKeys(ctx context.Context, request GetApiKeysRequestObject) (GetApiKeysResponseObject, error) {
+
+	principal, ok := middleware.PrincipalFromContext(ctx)
+	if !ok {
+		return GetApiKeys401JSONResponse{}, errlib.NewAppErrorWithLog(errors.New("missing principal"), errlib.ErrCodeUnauthorized)
+	}
+
+	userID := principal.UserID
+
+	rows, err := a.Db.DB.QueryContext(ctx, `
+		SELECT id, name, prefix, scopes, created_at, last_used_at, expires_at
+		FROM api_keys
+		WHERE user_id = $1 AND revoked_at IS NULL
+		ORDER BY created_at DESC;
+	`, userID)
+	if err != nil {
+		return GetApiKeys500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBQuery)
+	}
+	defer rows.Close()
+
+	keys := []ApiKey{}
+	for rows.Next() {
+		var (
+			key        ApiKey
+			scopes     string
+			createdAt  sql.NullTime
+			lastUsedAt sql.NullTime
+			expiresAt  sql.NullTime
+		)
+		if err := rows.Scan(&key.Id, &key.Name, &key.Prefix, &scopes, &createdAt, &lastUsedAt, &expiresAt); err != nil {
+			return GetApiKeys500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBQuery)
+		}
+
+		key.Scopes = strings.Fields(scopes)
+		if createdAt.Valid {
+			key.CreatedAt = &createdAt.Time
+		}
+		if lastUsedAt.Valid {
+			key.LastUsedAt = &lastUsedAt.Time
+		}
+		if expiresAt.Valid {
+			key.ExpiresAt = &expiresAt.Time
+		}
+
+		keys = append(keys, key)
+	}
+
+	if err := rows.Err(); err != nil {
+		return GetApiKeys500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBQuery)
+	}
+
+	resp := ApiKeyListResponse{
+		Data:       keys,
+		Message:    "success get api keys",
+		StatusCode: http.StatusOK,
+	}
+
+	return GetApiKeys200JSONResponse(resp), nil
+}
+
+func (a *AuthImpl) PostApiKeys(ctx context.Context, request PostApiKeysRequestObject) (PostApiKeysResponseObject, error) {
+
+	principal, ok := middleware.PrincipalFromContext(ctx)
+	if !ok {
+		return PostApiKeys401JSONResponse{}, errlib.NewAppErrorWithLog(errors.New("missing principal"), errlib.ErrCodeUnauthorized)
+	}
+
+	userID := principal.UserID
+
+	name := strings.TrimSpace(request.Body.Name)
+	if name == "" {
+		return PostApiKeys400JSONResponse{}, errlib.NewAppError(errlib.ErrCodeInvalidInput).WithFieldErrors("name", "must not be empty")
+	}
+
+	// scopes are stored space separated
+	scopes := []string{}
+	if request.Body.Scopes != nil {
+		for _, scope := range *request.Body.Scopes {
+			if scope == "" || strings.ContainsFunc(scope, unicode.IsSpace) {
+				return PostApiKeys400JSONResponse{}, errlib.NewAppError(errlib.ErrCodeInvalidInput).WithFieldErrors("scopes", "scope must be a non-empty string without whitespace")
+			}
+			if !slices.Contains(scopes, scope) {
+				scopes = append(scopes, scope)
+			}
+		}
+	}
+
+	if request.Body.ExpiresAt != nil && !request.Body.ExpiresAt.After(time.Now()) {
+		return PostApiKeys400JSONResponse{}, errlib.NewAppError(errlib.ErrCodeInvalidInput).WithFieldErrors("expires_at", "must be in the future")
+	}
+
+	secret, err := jwt.GenerateOpaqueToken()
+	if err != nil {
+		return PostApiKeys500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeInternalServer)
+	}
+	key := apiKeyPrefix + secret
+
+	apiKey := ApiKey{
+		Id:        uuid.NewString(),
+		Name:      name,
+		Prefix:    key[:apiKeyDisplayLength],
+		Scopes:    scopes,
+		ExpiresAt: request.Body.ExpiresAt,
+	}
+	createdAt := time.Now()
+	apiKey.CreatedAt = &createdAt
+
+	if _, err := a.Db.DB.ExecContext(ctx, `
+		INSERT INTO api_keys (id, user_id, name, prefix, key_hash, scopes, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
+	`, apiKey.Id, userID, apiKey.Name, apiKey.Prefix, a.Jwt.HashToken(key), strings.Join(scopes, " "), request.Body.ExpiresAt, createdAt); err != nil {
+		return PostApiKeys500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBQuery)
+	}
+
+	resp := CreateApiKeyResponse{
+		Message:    "api key created, store it now as it will not be shown again",
+		StatusCode: http.StatusCreated,
+	}
+	resp.Data.Key = key
+	resp.Data.ApiKey = apiKey
+
+	return PostApiKeys201JSONResponse(resp), nil
+}
+
+func (a *AuthImpl) DeleteApiKeysId(ctx context.Context, request DeleteApiKeysIdRequestObject) (DeleteApiKeysIdResponseObject, error) {
+
+	principal, ok := middleware.PrincipalFromContext(ctx)
+	if !ok {
+		return DeleteApiKeysId401JSONResponse{}, errlib.NewAppErrorWithLog(errors.New("missing principal"), errlib.ErrCodeUnauthorized)
+	}
+
+	userID := principal.UserID
+
+	// only keys owned by current user can be revoked, row is kept for last_used_at history
+	result, err := a.Db.DB.ExecContext(ctx, `
+		UPDATE api_keys
+		SET revoked_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL;
+	`, request.Id, userID)
+	if err != nil {
+		return DeleteApiKeysId500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBQuery)
+	}
+
+	revoked, err := result.RowsAffected()
+	if err != nil {
+		return DeleteApiKeysId500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBQuery)
+	}
+
+	if revoked == 0 {
+		return DeleteApiKeysId404JSONResponse{}, errlib.NewAppError(errlib.ErrCodeAPIKeyNotFound)
+	}
+
+	resp := common.BaseSuccessResponse{
+		Message:    "success revoke api key",
+		StatusCode: http.StatusOK,
+	}
+
+	return DeleteApiKeysId200JSONResponse(resp), nil
+}
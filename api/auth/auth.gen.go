@@ -14,14 +14,99 @@ import (
 	"net/url"
 	"path"
 	"strings"
+	"time"
 
 	externalRef0 "oapi-to-rest/api/common"
 
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/gin-gonic/gin"
+	"github.com/oapi-codegen/runtime"
 	strictgin "github.com/oapi-codegen/runtime/strictmiddleware/gin"
 )
 
+const (
+	BasicAuthScopes  = "basicAuth.Scopes"
+	BearerAuthScopes = "bearerAuth.Scopes"
+)
+
+// Defines values for IntrospectionResponseTokenType.
+const (
+	IntrospectionResponseTokenTypeAccessToken  IntrospectionResponseTokenType = "access_token"
+	IntrospectionResponseTokenTypeRefreshToken IntrospectionResponseTokenType = "refresh_token"
+)
+
+// Defines values for TokenRequestTokenTypeHint.
+const (
+	TokenRequestTokenTypeHintAccessToken  TokenRequestTokenTypeHint = "access_token"
+	TokenRequestTokenTypeHintRefreshToken TokenRequestTokenTypeHint = "refresh_token"
+)
+
+// ApiKey defines model for ApiKey.
+type ApiKey struct {
+	CreatedAt  *time.Time `json:"created_at,omitempty"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	Id         string     `json:"id"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	Name       string     `json:"name"`
+
+	// Prefix first characters of the key, to recognize it
+	Prefix string   `json:"prefix"`
+	Scopes []string `json:"scopes"`
+}
+
+// ApiKeyListResponse defines model for ApiKeyListResponse.
+type ApiKeyListResponse struct {
+	Data       []ApiKey `json:"data"`
+	Message    string   `json:"message"`
+	StatusCode int      `json:"status_code"`
+}
+
+// ChangePasswordRequest defines model for ChangePasswordRequest.
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password"`
+	NewPassword     string `json:"new_password"`
+
+	// RevokeOtherSessions revoke all sessions except the current one
+	RevokeOtherSessions *bool `json:"revoke_other_sessions,omitempty"`
+}
+
+// ClientCredentialsRequest defines model for ClientCredentialsRequest.
+type ClientCredentialsRequest struct {
+	// GrantType only client_credentials is supported
+	GrantType string `json:"grant_type"`
+
+	// Scope space separated, defaults to every scope of the client
+	Scope *string `json:"scope,omitempty"`
+}
+
+// ClientCredentialsResponse defines model for ClientCredentialsResponse.
+type ClientCredentialsResponse struct {
+	AccessToken string  `json:"access_token"`
+	ExpiresIn   int64   `json:"expires_in"`
+	Scope       *string `json:"scope,omitempty"`
+	TokenType   string  `json:"token_type"`
+}
+
+// CreateApiKeyRequest defines model for CreateApiKeyRequest.
+type CreateApiKeyRequest struct {
+	// ExpiresAt key never expires when omitted
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	Name      string     `json:"name"`
+	Scopes    *[]string  `json:"scopes,omitempty"`
+}
+
+// CreateApiKeyResponse defines model for CreateApiKeyResponse.
+type CreateApiKeyResponse struct {
+	Data struct {
+		ApiKey ApiKey `json:"api_key"`
+
+		// Key secret key, send as X-API-Key header
+		Key string `json:"key"`
+	} `json:"data"`
+	Message    string `json:"message"`
+	StatusCode int    `json:"status_code"`
+}
+
 // ErrorResponse defines model for ErrorResponse.
 type ErrorResponse struct {
 	Error      string  `json:"error"`
@@ -31,6 +116,51 @@ type ErrorResponse struct {
 	Trace      string  `json:"trace"`
 }
 
+// ForgotPasswordRequest defines model for ForgotPasswordRequest.
+type ForgotPasswordRequest struct {
+	Email string `json:"email"`
+}
+
+// ImpersonateRequest defines model for ImpersonateRequest.
+type ImpersonateRequest struct {
+	// Reason why support needs to act as the user, kept in the audit trail
+	Reason string `json:"reason"`
+	UserId int64  `json:"user_id"`
+}
+
+// ImpersonateResponse defines model for ImpersonateResponse.
+type ImpersonateResponse struct {
+	Data struct {
+		Email     string    `json:"email"`
+		ExpiresAt time.Time `json:"expires_at"`
+		Token     string    `json:"token"`
+		UserId    int64     `json:"user_id"`
+	} `json:"data"`
+	Message    string `json:"message"`
+	StatusCode int    `json:"status_code"`
+}
+
+// IntrospectionResponse defines model for IntrospectionResponse.
+type IntrospectionResponse struct {
+	Active    bool                            `json:"active"`
+	Aud       *string                         `json:"aud,omitempty"`
+	ClientId  *string                         `json:"client_id,omitempty"`
+	Exp       *int64                          `json:"exp,omitempty"`
+	Iat       *int64                          `json:"iat,omitempty"`
+	Iss       *string                         `json:"iss,omitempty"`
+	Jti       *string                         `json:"jti,omitempty"`
+	Nbf       *int64                          `json:"nbf,omitempty"`
+	Roles     *[]string                       `json:"roles,omitempty"`
+	Scope     *string                         `json:"scope,omitempty"`
+	Sid       *string                         `json:"sid,omitempty"`
+	Sub       *string                         `json:"sub,omitempty"`
+	TokenType *IntrospectionResponseTokenType `json:"token_type,omitempty"`
+	Username  *string                         `json:"username,omitempty"`
+}
+
+// IntrospectionResponseTokenType defines model for IntrospectionResponse.TokenType.
+type IntrospectionResponseTokenType string
+
 // LoginRequest defines model for LoginRequest.
 type LoginRequest struct {
 	Email    string `json:"email"`
@@ -40,7 +170,14 @@ type LoginRequest struct {
 // LoginResponse defines model for LoginResponse.
 type LoginResponse struct {
 	Data *struct {
-		Email        *string `json:"email,omitempty"`
+		Email *string `json:"email,omitempty"`
+
+		// MfaEnrollmentRequired session is limited to totp enrollment until mfa is enabled
+		MfaEnrollmentRequired *bool `json:"mfa_enrollment_required,omitempty"`
+
+		// MfaRequired password accepted, complete login at /login/mfa with mfa_token
+		MfaRequired  *bool   `json:"mfa_required,omitempty"`
+		MfaToken     *string `json:"mfa_token,omitempty"`
 		RefreshToken *string `json:"refresh_token,omitempty"`
 		Token        *string `json:"token,omitempty"`
 	} `json:"data,omitempty"`
@@ -48,6 +185,41 @@ type LoginResponse struct {
 	StatusCode int    `json:"status_code"`
 }
 
+// LogoutResponse defines model for LogoutResponse.
+type LogoutResponse struct {
+	Data *struct {
+		RevokedSessions *int `json:"revoked_sessions,omitempty"`
+	} `json:"data,omitempty"`
+	Message    string `json:"message"`
+	StatusCode int    `json:"status_code"`
+}
+
+// MagicLinkRequest defines model for MagicLinkRequest.
+type MagicLinkRequest struct {
+	Email string `json:"email"`
+}
+
+// MagicLinkVerifyRequest defines model for MagicLinkVerifyRequest.
+type MagicLinkVerifyRequest struct {
+	Token string `json:"token"`
+}
+
+// MfaLoginRequest defines model for MfaLoginRequest.
+type MfaLoginRequest struct {
+	// Code totp code or recovery code
+	Code     string `json:"code"`
+	MfaToken string `json:"mfa_token"`
+}
+
+// RecoveryCodesResponse defines model for RecoveryCodesResponse.
+type RecoveryCodesResponse struct {
+	Data struct {
+		RecoveryCodes []string `json:"recovery_codes"`
+	} `json:"data"`
+	Message    string `json:"message"`
+	StatusCode int    `json:"status_code"`
+}
+
 // RefreshRequest defines model for RefreshRequest.
 type RefreshRequest struct {
 	RefreshToken *string `json:"refresh_token,omitempty"`
@@ -78,9 +250,156 @@ type RegisterResponse struct {
 	StatusCode int    `json:"status_code"`
 }
 
+// ResendVerificationRequest defines model for ResendVerificationRequest.
+type ResendVerificationRequest struct {
+	Email string `json:"email"`
+}
+
+// ResetPasswordRequest defines model for ResetPasswordRequest.
+type ResetPasswordRequest struct {
+	NewPassword string `json:"new_password"`
+	Token       string `json:"token"`
+}
+
+// Role defines model for Role.
+type Role struct {
+	Description *string  `json:"description,omitempty"`
+	Name        string   `json:"name"`
+	Permissions []string `json:"permissions"`
+}
+
+// RoleListResponse defines model for RoleListResponse.
+type RoleListResponse struct {
+	Data       []Role `json:"data"`
+	Message    string `json:"message"`
+	StatusCode int    `json:"status_code"`
+}
+
+// Session defines model for Session.
+type Session struct {
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	Id        string     `json:"id"`
+	IpAddress *string    `json:"ip_address,omitempty"`
+	IsCurrent bool       `json:"is_current"`
+
+	// IsImpersonation session was opened by an admin acting as the user
+	IsImpersonation *bool   `json:"is_impersonation,omitempty"`
+	UserAgent       *string `json:"user_agent,omitempty"`
+}
+
+// SessionListResponse defines model for SessionListResponse.
+type SessionListResponse struct {
+	Data       []Session `json:"data"`
+	Message    string    `json:"message"`
+	StatusCode int       `json:"status_code"`
+}
+
+// TokenRequest defines model for TokenRequest.
+type TokenRequest struct {
+	Token         string                     `json:"token"`
+	TokenTypeHint *TokenRequestTokenTypeHint `json:"token_type_hint,omitempty"`
+}
+
+// TokenRequestTokenTypeHint defines model for TokenRequest.TokenTypeHint.
+type TokenRequestTokenTypeHint string
+
+// TotpConfirmRequest defines model for TotpConfirmRequest.
+type TotpConfirmRequest struct {
+	Code string `json:"code"`
+}
+
+// TotpSetupResponse defines model for TotpSetupResponse.
+type TotpSetupResponse struct {
+	Data struct {
+		OtpauthUri string `json:"otpauth_uri"`
+		Secret     string `json:"secret"`
+	} `json:"data"`
+	Message    string `json:"message"`
+	StatusCode int    `json:"status_code"`
+}
+
+// UserInfoResponse defines model for UserInfoResponse.
+type UserInfoResponse struct {
+	Email         string   `json:"email"`
+	EmailVerified bool     `json:"email_verified"`
+	FamilyName    *string  `json:"family_name,omitempty"`
+	GivenName     *string  `json:"given_name,omitempty"`
+	Name          *string  `json:"name,omitempty"`
+	Roles         []string `json:"roles"`
+	Sub           string   `json:"sub"`
+}
+
+// UserRoles defines model for UserRoles.
+type UserRoles struct {
+	Permissions []string `json:"permissions"`
+	Roles       []string `json:"roles"`
+	UserId      int64    `json:"user_id"`
+}
+
+// UserRolesResponse defines model for UserRolesResponse.
+type UserRolesResponse struct {
+	Data       UserRoles `json:"data"`
+	Message    string    `json:"message"`
+	StatusCode int       `json:"status_code"`
+}
+
+// GetEmailVerifyParams defines parameters for GetEmailVerify.
+type GetEmailVerifyParams struct {
+	Token string `form:"token" json:"token"`
+}
+
+// GetOauthProviderCallbackParams defines parameters for GetOauthProviderCallback.
+type GetOauthProviderCallbackParams struct {
+	State string  `form:"state" json:"state"`
+	Code  *string `form:"code,omitempty" json:"code,omitempty"`
+
+	// Error set by provider when authorization was denied
+	Error *string `form:"error,omitempty" json:"error,omitempty"`
+}
+
+// PostApiKeysJSONRequestBody defines body for PostApiKeys for application/json ContentType.
+type PostApiKeysJSONRequestBody = CreateApiKeyRequest
+
+// PostEmailVerifyResendJSONRequestBody defines body for PostEmailVerifyResend for application/json ContentType.
+type PostEmailVerifyResendJSONRequestBody = ResendVerificationRequest
+
+// PostImpersonateJSONRequestBody defines body for PostImpersonate for application/json ContentType.
+type PostImpersonateJSONRequestBody = ImpersonateRequest
+
 // PostLoginJSONRequestBody defines body for PostLogin for application/json ContentType.
 type PostLoginJSONRequestBody = LoginRequest
 
+// PostLoginMfaJSONRequestBody defines body for PostLoginMfa for application/json ContentType.
+type PostLoginMfaJSONRequestBody = MfaLoginRequest
+
+// PostMagicLinkRequestJSONRequestBody defines body for PostMagicLinkRequest for application/json ContentType.
+type PostMagicLinkRequestJSONRequestBody = MagicLinkRequest
+
+// PostMagicLinkVerifyJSONRequestBody defines body for PostMagicLinkVerify for application/json ContentType.
+type PostMagicLinkVerifyJSONRequestBody = MagicLinkVerifyRequest
+
+// PostMfaTotpConfirmJSONRequestBody defines body for PostMfaTotpConfirm for application/json ContentType.
+type PostMfaTotpConfirmJSONRequestBody = TotpConfirmRequest
+
+// PostOauthIntrospectFormdataRequestBody defines body for PostOauthIntrospect for application/x-www-form-urlencoded ContentType.
+type PostOauthIntrospectFormdataRequestBody = TokenRequest
+
+// PostOauthRevokeFormdataRequestBody defines body for PostOauthRevoke for application/x-www-form-urlencoded ContentType.
+type PostOauthRevokeFormdataRequestBody = TokenRequest
+
+// PostOauthTokenFormdataRequestBody defines body for PostOauthToken for application/x-www-form-urlencoded ContentType.
+type PostOauthTokenFormdataRequestBody = ClientCredentialsRequest
+
+// PostPasswordChangeJSONRequestBody defines body for PostPasswordChange for application/json ContentType.
+type PostPasswordChangeJSONRequestBody = ChangePasswordRequest
+
+// PostPasswordForgotJSONRequestBody defines body for PostPasswordForgot for application/json ContentType.
+type PostPasswordForgotJSONRequestBody = ForgotPasswordRequest
+
+// PostPasswordResetJSONRequestBody defines body for PostPasswordReset for application/json ContentType.
+type PostPasswordResetJSONRequestBody = ResetPasswordRequest
+
 // PostRefreshJSONRequestBody defines body for PostRefresh for application/json ContentType.
 type PostRefreshJSONRequestBody = RefreshRequest
 
@@ -89,15 +408,99 @@ type PostRegisterJSONRequestBody = RegisterRequest
 
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// list api keys of current user
+	// (GET /api-keys)
+	GetApiKeys(c *gin.Context)
+	// create api key for machine clients acting as current user
+	// (POST /api-keys)
+	PostApiKeys(c *gin.Context)
+	// revoke an api key of current user
+	// (DELETE /api-keys/{id})
+	DeleteApiKeysId(c *gin.Context, id string)
+	// verify email address with the link sent on registration
+	// (GET /email/verify)
+	GetEmailVerify(c *gin.Context, params GetEmailVerifyParams)
+	// resend email verification link
+	// (POST /email/verify/resend)
+	PostEmailVerifyResend(c *gin.Context)
+	// issue short-lived token acting as another user, admin only
+	// (POST /impersonate)
+	PostImpersonate(c *gin.Context)
 	// login with credentials
 	// (POST /login)
 	PostLogin(c *gin.Context)
+	// complete login with second factor
+	// (POST /login/mfa)
+	PostLoginMfa(c *gin.Context)
+	// revoke current session
+	// (POST /logout)
+	PostLogout(c *gin.Context)
+	// revoke every session of current user
+	// (POST /logout-all)
+	PostLogoutAll(c *gin.Context)
+	// request passwordless login link by email
+	// (POST /magic-link/request)
+	PostMagicLinkRequest(c *gin.Context)
+	// login with token of a login link, from the browser that requested it
+	// (POST /magic-link/verify)
+	PostMagicLinkVerify(c *gin.Context)
+	// confirm totp enrollment with a code from the authenticator app
+	// (POST /mfa/totp/confirm)
+	PostMfaTotpConfirm(c *gin.Context)
+	// start totp enrollment for current user
+	// (POST /mfa/totp/setup)
+	PostMfaTotpSetup(c *gin.Context)
+	// RFC 7662 introspection of an access or refresh token, for registered clients
+	// (POST /oauth/introspect)
+	PostOauthIntrospect(c *gin.Context)
+	// RFC 7009 revocation of an access or refresh token, for registered clients
+	// (POST /oauth/revoke)
+	PostOauthRevoke(c *gin.Context)
+	// RFC 6749 client_credentials grant, token of a registered client acting as itself
+	// (POST /oauth/token)
+	PostOauthToken(c *gin.Context)
+	// complete social login with authorization code returned by provider
+	// (GET /oauth/{provider}/callback)
+	GetOauthProviderCallback(c *gin.Context, provider string, params GetOauthProviderCallbackParams)
+	// redirect to provider to start social login
+	// (GET /oauth/{provider}/start)
+	GetOauthProviderStart(c *gin.Context, provider string)
+	// change password of current user
+	// (POST /password/change)
+	PostPasswordChange(c *gin.Context)
+	// request password reset link by email
+	// (POST /password/forgot)
+	PostPasswordForgot(c *gin.Context)
+	// reset password with reset token
+	// (POST /password/reset)
+	PostPasswordReset(c *gin.Context)
 	// login with credentials
 	// (POST /refresh)
 	PostRefresh(c *gin.Context)
 	// register new user body request
 	// (POST /register)
 	PostRegister(c *gin.Context)
+	// list roles and their permissions, requires roles:read
+	// (GET /roles)
+	GetRoles(c *gin.Context)
+	// list active sessions of current user
+	// (GET /sessions)
+	GetSessions(c *gin.Context)
+	// revoke a session of current user
+	// (DELETE /sessions/{id})
+	DeleteSessionsId(c *gin.Context, id string)
+	// OpenID Connect claims of the user the bearer token belongs to
+	// (GET /userinfo)
+	GetUserinfo(c *gin.Context)
+	// roles and permissions of a user, requires roles:read
+	// (GET /users/{user_id}/roles)
+	GetUsersUserIdRoles(c *gin.Context, userId int64)
+	// revoke a role of a user, requires roles:manage. applies to tokens issued after the change
+	// (DELETE /users/{user_id}/roles/{role})
+	DeleteUsersUserIdRolesRole(c *gin.Context, userId int64, role string)
+	// grant a role to a user, requires roles:manage. applies to tokens issued after the change
+	// (PUT /users/{user_id}/roles/{role})
+	PutUsersUserIdRolesRole(c *gin.Context, userId int64, role string)
 }
 
 // ServerInterfaceWrapper converts contexts to parameters.
@@ -109,8 +512,10 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc func(c *gin.Context)
 
-// PostLogin operation middleware
-func (siw *ServerInterfaceWrapper) PostLogin(c *gin.Context) {
+// GetApiKeys operation middleware
+func (siw *ServerInterfaceWrapper) GetApiKeys(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{})
 
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
@@ -119,11 +524,13 @@ func (siw *ServerInterfaceWrapper) PostLogin(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.PostLogin(c)
+	siw.Handler.GetApiKeys(c)
 }
 
-// PostRefresh operation middleware
-func (siw *ServerInterfaceWrapper) PostRefresh(c *gin.Context) {
+// PostApiKeys operation middleware
+func (siw *ServerInterfaceWrapper) PostApiKeys(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{})
 
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
@@ -132,11 +539,24 @@ func (siw *ServerInterfaceWrapper) PostRefresh(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.PostRefresh(c)
+	siw.Handler.PostApiKeys(c)
 }
 
-// PostRegister operation middleware
-func (siw *ServerInterfaceWrapper) PostRegister(c *gin.Context) {
+// DeleteApiKeysId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteApiKeysId(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
 
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
@@ -145,176 +565,2715 @@ func (siw *ServerInterfaceWrapper) PostRegister(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.PostRegister(c)
+	siw.Handler.DeleteApiKeysId(c, id)
 }
 
-// GinServerOptions provides options for the Gin server.
-type GinServerOptions struct {
-	BaseURL      string
-	Middlewares  []MiddlewareFunc
-	ErrorHandler func(*gin.Context, error, int)
-}
+// GetEmailVerify operation middleware
+func (siw *ServerInterfaceWrapper) GetEmailVerify(c *gin.Context) {
 
-// RegisterHandlers creates http.Handler with routing matching OpenAPI spec.
-func RegisterHandlers(router gin.IRouter, si ServerInterface) {
-	RegisterHandlersWithOptions(router, si, GinServerOptions{})
-}
+	var err error
 
-// RegisterHandlersWithOptions creates http.Handler with additional options
-func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options GinServerOptions) {
-	errorHandler := options.ErrorHandler
-	if errorHandler == nil {
-		errorHandler = func(c *gin.Context, err error, statusCode int) {
-			c.JSON(statusCode, gin.H{"msg": err.Error()})
-		}
-	}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetEmailVerifyParams
 
-	wrapper := ServerInterfaceWrapper{
-		Handler:            si,
-		HandlerMiddlewares: options.Middlewares,
-		ErrorHandler:       errorHandler,
+	// ------------- Required query parameter "token" -------------
+
+	if paramValue := c.Query("token"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument token is required, but not found"), http.StatusBadRequest)
+		return
 	}
 
-	router.POST(options.BaseURL+"/login", wrapper.PostLogin)
-	router.POST(options.BaseURL+"/refresh", wrapper.PostRefresh)
-	router.POST(options.BaseURL+"/register", wrapper.PostRegister)
-}
+	err = runtime.BindQueryParameter("form", true, true, "token", c.Request.URL.Query(), &params.Token)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter token: %w", err), http.StatusBadRequest)
+		return
+	}
 
-type PostLoginRequestObject struct {
-	Body *PostLoginJSONRequestBody
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
 
-type PostLoginResponseObject interface {
-	VisitPostLoginResponse(w http.ResponseWriter) error
+	siw.Handler.GetEmailVerify(c, params)
 }
 
-type PostLogin200JSONResponse LoginResponse
+// PostEmailVerifyResend operation middleware
+func (siw *ServerInterfaceWrapper) PostEmailVerifyResend(c *gin.Context) {
 
-func (response PostLogin200JSONResponse) VisitPostLoginResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	siw.Handler.PostEmailVerifyResend(c)
 }
 
-type PostLogin401JSONResponse ErrorResponse
+// PostImpersonate operation middleware
+func (siw *ServerInterfaceWrapper) PostImpersonate(c *gin.Context) {
 
-func (response PostLogin401JSONResponse) VisitPostLoginResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	c.Set(BearerAuthScopes, []string{})
 
-	return json.NewEncoder(w).Encode(response)
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostImpersonate(c)
 }
 
-type PostLogin500JSONResponse externalRef0.StandardErrorResponse
+// PostLogin operation middleware
+func (siw *ServerInterfaceWrapper) PostLogin(c *gin.Context) {
 
-func (response PostLogin500JSONResponse) VisitPostLoginResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	siw.Handler.PostLogin(c)
 }
 
-type PostRefreshRequestObject struct {
-	Body *PostRefreshJSONRequestBody
-}
+// PostLoginMfa operation middleware
+func (siw *ServerInterfaceWrapper) PostLoginMfa(c *gin.Context) {
 
-type PostRefreshResponseObject interface {
-	VisitPostRefreshResponse(w http.ResponseWriter) error
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostLoginMfa(c)
 }
 
-type PostRefresh200JSONResponse RefreshResponse
+// PostLogout operation middleware
+func (siw *ServerInterfaceWrapper) PostLogout(c *gin.Context) {
 
-func (response PostRefresh200JSONResponse) VisitPostRefreshResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	c.Set(BearerAuthScopes, []string{})
 
-	return json.NewEncoder(w).Encode(response)
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostLogout(c)
 }
 
-type PostRefresh401JSONResponse externalRef0.StandardErrorResponse
+// PostLogoutAll operation middleware
+func (siw *ServerInterfaceWrapper) PostLogoutAll(c *gin.Context) {
 
-func (response PostRefresh401JSONResponse) VisitPostRefreshResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	c.Set(BearerAuthScopes, []string{})
 
-	return json.NewEncoder(w).Encode(response)
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostLogoutAll(c)
 }
 
-type PostRefresh500JSONResponse externalRef0.StandardErrorResponse
+// PostMagicLinkRequest operation middleware
+func (siw *ServerInterfaceWrapper) PostMagicLinkRequest(c *gin.Context) {
 
-func (response PostRefresh500JSONResponse) VisitPostRefreshResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	siw.Handler.PostMagicLinkRequest(c)
 }
 
-type PostRegisterRequestObject struct {
+// PostMagicLinkVerify operation middleware
+func (siw *ServerInterfaceWrapper) PostMagicLinkVerify(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostMagicLinkVerify(c)
+}
+
+// PostMfaTotpConfirm operation middleware
+func (siw *ServerInterfaceWrapper) PostMfaTotpConfirm(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostMfaTotpConfirm(c)
+}
+
+// PostMfaTotpSetup operation middleware
+func (siw *ServerInterfaceWrapper) PostMfaTotpSetup(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostMfaTotpSetup(c)
+}
+
+// PostOauthIntrospect operation middleware
+func (siw *ServerInterfaceWrapper) PostOauthIntrospect(c *gin.Context) {
+
+	c.Set(BasicAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostOauthIntrospect(c)
+}
+
+// PostOauthRevoke operation middleware
+func (siw *ServerInterfaceWrapper) PostOauthRevoke(c *gin.Context) {
+
+	c.Set(BasicAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostOauthRevoke(c)
+}
+
+// PostOauthToken operation middleware
+func (siw *ServerInterfaceWrapper) PostOauthToken(c *gin.Context) {
+
+	c.Set(BasicAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostOauthToken(c)
+}
+
+// GetOauthProviderCallback operation middleware
+func (siw *ServerInterfaceWrapper) GetOauthProviderCallback(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "provider" -------------
+	var provider string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "provider", c.Param("provider"), &provider, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter provider: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetOauthProviderCallbackParams
+
+	// ------------- Required query parameter "state" -------------
+
+	if paramValue := c.Query("state"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument state is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "state", c.Request.URL.Query(), &params.State)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter state: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "code" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "code", c.Request.URL.Query(), &params.Code)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter code: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "error" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "error", c.Request.URL.Query(), &params.Error)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter error: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetOauthProviderCallback(c, provider, params)
+}
+
+// GetOauthProviderStart operation middleware
+func (siw *ServerInterfaceWrapper) GetOauthProviderStart(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "provider" -------------
+	var provider string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "provider", c.Param("provider"), &provider, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter provider: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetOauthProviderStart(c, provider)
+}
+
+// PostPasswordChange operation middleware
+func (siw *ServerInterfaceWrapper) PostPasswordChange(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostPasswordChange(c)
+}
+
+// PostPasswordForgot operation middleware
+func (siw *ServerInterfaceWrapper) PostPasswordForgot(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostPasswordForgot(c)
+}
+
+// PostPasswordReset operation middleware
+func (siw *ServerInterfaceWrapper) PostPasswordReset(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostPasswordReset(c)
+}
+
+// PostRefresh operation middleware
+func (siw *ServerInterfaceWrapper) PostRefresh(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostRefresh(c)
+}
+
+// PostRegister operation middleware
+func (siw *ServerInterfaceWrapper) PostRegister(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostRegister(c)
+}
+
+// GetRoles operation middleware
+func (siw *ServerInterfaceWrapper) GetRoles(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetRoles(c)
+}
+
+// GetSessions operation middleware
+func (siw *ServerInterfaceWrapper) GetSessions(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetSessions(c)
+}
+
+// DeleteSessionsId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteSessionsId(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteSessionsId(c, id)
+}
+
+// GetUserinfo operation middleware
+func (siw *ServerInterfaceWrapper) GetUserinfo(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetUserinfo(c)
+}
+
+// GetUsersUserIdRoles operation middleware
+func (siw *ServerInterfaceWrapper) GetUsersUserIdRoles(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "user_id" -------------
+	var userId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", c.Param("user_id"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetUsersUserIdRoles(c, userId)
+}
+
+// DeleteUsersUserIdRolesRole operation middleware
+func (siw *ServerInterfaceWrapper) DeleteUsersUserIdRolesRole(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "user_id" -------------
+	var userId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", c.Param("user_id"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "role" -------------
+	var role string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "role", c.Param("role"), &role, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter role: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteUsersUserIdRolesRole(c, userId, role)
+}
+
+// PutUsersUserIdRolesRole operation middleware
+func (siw *ServerInterfaceWrapper) PutUsersUserIdRolesRole(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "user_id" -------------
+	var userId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", c.Param("user_id"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter user_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "role" -------------
+	var role string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "role", c.Param("role"), &role, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter role: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PutUsersUserIdRolesRole(c, userId, role)
+}
+
+// GinServerOptions provides options for the Gin server.
+type GinServerOptions struct {
+	BaseURL      string
+	Middlewares  []MiddlewareFunc
+	ErrorHandler func(*gin.Context, error, int)
+}
+
+// RegisterHandlers creates http.Handler with routing matching OpenAPI spec.
+func RegisterHandlers(router gin.IRouter, si ServerInterface) {
+	RegisterHandlersWithOptions(router, si, GinServerOptions{})
+}
+
+// RegisterHandlersWithOptions creates http.Handler with additional options
+func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options GinServerOptions) {
+	errorHandler := options.ErrorHandler
+	if errorHandler == nil {
+		errorHandler = func(c *gin.Context, err error, statusCode int) {
+			c.JSON(statusCode, gin.H{"msg": err.Error()})
+		}
+	}
+
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandler:       errorHandler,
+	}
+
+	router.GET(options.BaseURL+"/api-keys", wrapper.GetApiKeys)
+	router.POST(options.BaseURL+"/api-keys", wrapper.PostApiKeys)
+	router.DELETE(options.BaseURL+"/api-keys/:id", wrapper.DeleteApiKeysId)
+	router.GET(options.BaseURL+"/email/verify", wrapper.GetEmailVerify)
+	router.POST(options.BaseURL+"/email/verify/resend", wrapper.PostEmailVerifyResend)
+	router.POST(options.BaseURL+"/impersonate", wrapper.PostImpersonate)
+	router.POST(options.BaseURL+"/login", wrapper.PostLogin)
+	router.POST(options.BaseURL+"/login/mfa", wrapper.PostLoginMfa)
+	router.POST(options.BaseURL+"/logout", wrapper.PostLogout)
+	router.POST(options.BaseURL+"/logout-all", wrapper.PostLogoutAll)
+	router.POST(options.BaseURL+"/magic-link/request", wrapper.PostMagicLinkRequest)
+	router.POST(options.BaseURL+"/magic-link/verify", wrapper.PostMagicLinkVerify)
+	router.POST(options.BaseURL+"/mfa/totp/confirm", wrapper.PostMfaTotpConfirm)
+	router.POST(options.BaseURL+"/mfa/totp/setup", wrapper.PostMfaTotpSetup)
+	router.POST(options.BaseURL+"/oauth/introspect", wrapper.PostOauthIntrospect)
+	router.POST(options.BaseURL+"/oauth/revoke", wrapper.PostOauthRevoke)
+	router.POST(options.BaseURL+"/oauth/token", wrapper.PostOauthToken)
+	router.GET(options.BaseURL+"/oauth/:provider/callback", wrapper.GetOauthProviderCallback)
+	router.GET(options.BaseURL+"/oauth/:provider/start", wrapper.GetOauthProviderStart)
+	router.POST(options.BaseURL+"/password/change", wrapper.PostPasswordChange)
+	router.POST(options.BaseURL+"/password/forgot", wrapper.PostPasswordForgot)
+	router.POST(options.BaseURL+"/password/reset", wrapper.PostPasswordReset)
+	router.POST(options.BaseURL+"/refresh", wrapper.PostRefresh)
+	router.POST(options.BaseURL+"/register", wrapper.PostRegister)
+	router.GET(options.BaseURL+"/roles", wrapper.GetRoles)
+	router.GET(options.BaseURL+"/sessions", wrapper.GetSessions)
+	router.DELETE(options.BaseURL+"/sessions/:id", wrapper.DeleteSessionsId)
+	router.GET(options.BaseURL+"/userinfo", wrapper.GetUserinfo)
+	router.GET(options.BaseURL+"/users/:user_id/roles", wrapper.GetUsersUserIdRoles)
+	router.DELETE(options.BaseURL+"/users/:user_id/roles/:role", wrapper.DeleteUsersUserIdRolesRole)
+	router.PUT(options.BaseURL+"/users/:user_id/roles/:role", wrapper.PutUsersUserIdRolesRole)
+}
+
+type GetApiKeysRequestObject struct {
+}
+
+type GetApiKeysResponseObject interface {
+	VisitGetApiKeysResponse(w http.ResponseWriter) error
+}
+
+type GetApiKeys200JSONResponse ApiKeyListResponse
+
+func (response GetApiKeys200JSONResponse) VisitGetApiKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetApiKeys401JSONResponse externalRef0.StandardErrorResponse
+
+func (response GetApiKeys401JSONResponse) VisitGetApiKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetApiKeys500JSONResponse externalRef0.StandardErrorResponse
+
+func (response GetApiKeys500JSONResponse) VisitGetApiKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostApiKeysRequestObject struct {
+	Body *PostApiKeysJSONRequestBody
+}
+
+type PostApiKeysResponseObject interface {
+	VisitPostApiKeysResponse(w http.ResponseWriter) error
+}
+
+type PostApiKeys201JSONResponse CreateApiKeyResponse
+
+func (response PostApiKeys201JSONResponse) VisitPostApiKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostApiKeys400JSONResponse externalRef0.StandardErrorResponse
+
+func (response PostApiKeys400JSONResponse) VisitPostApiKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostApiKeys401JSONResponse externalRef0.StandardErrorResponse
+
+func (response PostApiKeys401JSONResponse) VisitPostApiKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostApiKeys500JSONResponse externalRef0.StandardErrorResponse
+
+func (response PostApiKeys500JSONResponse) VisitPostApiKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteApiKeysIdRequestObject struct {
+	Id string `json:"id"`
+}
+
+type DeleteApiKeysIdResponseObject interface {
+	VisitDeleteApiKeysIdResponse(w http.ResponseWriter) error
+}
+
+type DeleteApiKeysId200JSONResponse externalRef0.BaseSuccessResponse
+
+func (response DeleteApiKeysId200JSONResponse) VisitDeleteApiKeysIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteApiKeysId401JSONResponse externalRef0.StandardErrorResponse
+
+func (response DeleteApiKeysId401JSONResponse) VisitDeleteApiKeysIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteApiKeysId404JSONResponse externalRef0.StandardErrorResponse
+
+func (response DeleteApiKeysId404JSONResponse) VisitDeleteApiKeysIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteApiKeysId500JSONResponse externalRef0.StandardErrorResponse
+
+func (response DeleteApiKeysId500JSONResponse) VisitDeleteApiKeysIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetEmailVerifyRequestObject struct {
+	Params GetEmailVerifyParams
+}
+
+type GetEmailVerifyResponseObject interface {
+	VisitGetEmailVerifyResponse(w http.ResponseWriter) error
+}
+
+type GetEmailVerify200JSONResponse externalRef0.BaseSuccessResponse
+
+func (response GetEmailVerify200JSONResponse) VisitGetEmailVerifyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetEmailVerify400JSONResponse externalRef0.StandardErrorResponse
+
+func (response GetEmailVerify400JSONResponse) VisitGetEmailVerifyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetEmailVerify500JSONResponse externalRef0.StandardErrorResponse
+
+func (response GetEmailVerify500JSONResponse) VisitGetEmailVerifyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEmailVerifyResendRequestObject struct {
+	Body *PostEmailVerifyResendJSONRequestBody
+}
+
+type PostEmailVerifyResendResponseObject interface {
+	VisitPostEmailVerifyResendResponse(w http.ResponseWriter) error
+}
+
+type PostEmailVerifyResend200JSONResponse externalRef0.BaseSuccessResponse
+
+func (response PostEmailVerifyResend200JSONResponse) VisitPostEmailVerifyResendResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEmailVerifyResend500JSONResponse externalRef0.StandardErrorResponse
+
+func (response PostEmailVerifyResend500JSONResponse) VisitPostEmailVerifyResendResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostImpersonateRequestObject struct {
+	Body *PostImpersonateJSONRequestBody
+}
+
+type PostImpersonateResponseObject interface {
+	VisitPostImpersonateResponse(w http.ResponseWriter) error
+}
+
+type PostImpersonate200JSONResponse ImpersonateResponse
+
+func (response PostImpersonate200JSONResponse) VisitPostImpersonateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostImpersonate400JSONResponse externalRef0.StandardErrorResponse
+
+func (response PostImpersonate400JSONResponse) VisitPostImpersonateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostImpersonate401JSONResponse externalRef0.StandardErrorResponse
+
+func (response PostImpersonate401JSONResponse) VisitPostImpersonateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostImpersonate403JSONResponse externalRef0.StandardErrorResponse
+
+func (response PostImpersonate403JSONResponse) VisitPostImpersonateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostImpersonate404JSONResponse externalRef0.StandardErrorResponse
+
+func (response PostImpersonate404JSONResponse) VisitPostImpersonateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostImpersonate500JSONResponse externalRef0.StandardErrorResponse
+
+func (response PostImpersonate500JSONResponse) VisitPostImpersonateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostLoginRequestObject struct {
+	Body *PostLoginJSONRequestBody
+}
+
+type PostLoginResponseObject interface {
+	VisitPostLoginResponse(w http.ResponseWriter) error
+}
+
+type PostLogin200JSONResponse LoginResponse
+
+func (response PostLogin200JSONResponse) VisitPostLoginResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostLogin401JSONResponse ErrorResponse
+
+func (response PostLogin401JSONResponse) VisitPostLoginResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostLogin403JSONResponse externalRef0.StandardErrorResponse
+
+func (response PostLogin403JSONResponse) VisitPostLoginResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostLogin409JSONResponse externalRef0.StandardErrorResponse
+
+func (response PostLogin409JSONResponse) VisitPostLoginResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostLogin429ResponseHeaders struct {
+	RetryAfter int
+}
+
+type PostLogin429JSONResponse struct {
+	Body    externalRef0.StandardErrorResponse
+	Headers PostLogin429ResponseHeaders
+}
+
+func (response PostLogin429JSONResponse) VisitPostLoginResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostLogin500JSONResponse externalRef0.StandardErrorResponse
+
+func (response PostLogin500JSONResponse) VisitPostLoginResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostLoginMfaRequestObject struct {
+	Body *PostLoginMfaJSONRequestBody
+}
+
+type PostLoginMfaResponseObject interface {
+	VisitPostLoginMfaResponse(w http.ResponseWriter) error
+}
+
+type PostLoginMfa200JSONResponse LoginResponse
+
+func (response PostLoginMfa200JSONResponse) VisitPostLoginMfaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostLoginMfa401JSONResponse externalRef0.StandardErrorResponse
+
+func (response PostLoginMfa401JSONResponse) VisitPostLoginMfaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostLoginMfa409JSONResponse externalRef0.StandardErrorResponse
+
+func (response PostLoginMfa409JSONResponse) VisitPostLoginMfaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostLoginMfa429ResponseHeaders struct {
+	RetryAfter int
+}
+
+type PostLoginMfa429JSONResponse struct {
+	Body    externalRef0.StandardErrorResponse
+	Headers PostLoginMfa429ResponseHeaders
+}
+
+func (response PostLoginMfa429JSONResponse) VisitPostLoginMfaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostLoginMfa500JSONResponse externalRef0.StandardErrorResponse
+
+func (response PostLoginMfa500JSONResponse) VisitPostLoginMfaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostLogoutRequestObject struct {
+}
+
+type PostLogoutResponseObject interface {
+	VisitPostLogoutResponse(w http.ResponseWriter) error
+}
+
+type PostLogout200JSONResponse LogoutResponse
+
+func (response PostLogout200JSONResponse) VisitPostLogoutResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostLogout401JSONResponse externalRef0.StandardErrorResponse
+
+func (response PostLogout401JSONResponse) VisitPostLogoutResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostLogout500JSONResponse externalRef0.StandardErrorResponse
+
+func (response PostLogout500JSONResponse) VisitPostLogoutResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostLogoutAllRequestObject struct {
+}
+
+type PostLogoutAllResponseObject interface {
+	VisitPostLogoutAllResponse(w http.ResponseWriter) error
+}
+
+type PostLogoutAll200JSONResponse LogoutResponse
+
+func (response PostLogoutAll200JSONResponse) VisitPostLogoutAllResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostLogoutAll401JSONResponse externalRef0.StandardErrorResponse
+
+func (response PostLogoutAll401JSONResponse) VisitPostLogoutAllResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostLogoutAll500JSONResponse externalRef0.StandardErrorResponse
+
+func (response PostLogoutAll500JSONResponse) VisitPostLogoutAllResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostMagicLinkRequestRequestObject struct {
+	Body *PostMagicLinkRequestJSONRequestBody
+}
+
+type PostMagicLinkRequestResponseObject interface {
+	VisitPostMagicLinkRequestResponse(w http.ResponseWriter) error
+}
+
+type PostMagicLinkRequest200JSONResponse externalRef0.BaseSuccessResponse
+
+func (response PostMagicLinkRequest200JSONResponse) VisitPostMagicLinkRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostMagicLinkRequest500JSONResponse externalRef0.StandardErrorResponse
+
+func (response PostMagicLinkRequest500JSONResponse) VisitPostMagicLinkRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostMagicLinkVerifyRequestObject struct {
+	Body *PostMagicLinkVerifyJSONRequestBody
+}
+
+type PostMagicLinkVerifyResponseObject interface {
+	VisitPostMagicLinkVerifyResponse(w http.ResponseWriter) error
+}
+
+type PostMagicLinkVerify200JSONResponse LoginResponse
+
+func (response PostMagicLinkVerify200JSONResponse) VisitPostMagicLinkVerifyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostMagicLinkVerify400JSONResponse externalRef0.StandardErrorResponse
+
+func (response PostMagicLinkVerify400JSONResponse) VisitPostMagicLinkVerifyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostMagicLinkVerify409JSONResponse externalRef0.StandardErrorResponse
+
+func (response PostMagicLinkVerify409JSONResponse) VisitPostMagicLinkVerifyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostMagicLinkVerify500JSONResponse externalRef0.StandardErrorResponse
+
+func (response PostMagicLinkVerify500JSONResponse) VisitPostMagicLinkVerifyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostMfaTotpConfirmRequestObject struct {
+	Body *PostMfaTotpConfirmJSONRequestBody
+}
+
+type PostMfaTotpConfirmResponseObject interface {
+	VisitPostMfaTotpConfirmResponse(w http.ResponseWriter) error
+}
+
+type PostMfaTotpConfirm200JSONResponse RecoveryCodesResponse
+
+func (response PostMfaTotpConfirm200JSONResponse) VisitPostMfaTotpConfirmResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostMfaTotpConfirm400JSONResponse externalRef0.StandardErrorResponse
+
+func (response PostMfaTotpConfirm400JSONResponse) VisitPostMfaTotpConfirmResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostMfaTotpConfirm401JSONResponse externalRef0.StandardErrorResponse
+
+func (response PostMfaTotpConfirm401JSONResponse) VisitPostMfaTotpConfirmResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostMfaTotpConfirm500JSONResponse externalRef0.StandardErrorResponse
+
+func (response PostMfaTotpConfirm500JSONResponse) VisitPostMfaTotpConfirmResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostMfaTotpSetupRequestObject struct {
+}
+
+type PostMfaTotpSetupResponseObject interface {
+	VisitPostMfaTotpSetupResponse(w http.ResponseWriter) error
+}
+
+type PostMfaTotpSetup200JSONResponse TotpSetupResponse
+
+func (response PostMfaTotpSetup200JSONResponse) VisitPostMfaTotpSetupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostMfaTotpSetup400JSONResponse externalRef0.StandardErrorResponse
+
+func (response PostMfaTotpSetup400JSONResponse) VisitPostMfaTotpSetupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostMfaTotpSetup401JSONResponse externalRef0.StandardErrorResponse
+
+func (response PostMfaTotpSetup401JSONResponse) VisitPostMfaTotpSetupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostMfaTotpSetup500JSONResponse externalRef0.StandardErrorResponse
+
+func (response PostMfaTotpSetup500JSONResponse) VisitPostMfaTotpSetupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostOauthIntrospectRequestObject struct {
+	Body *PostOauthIntrospectFormdataRequestBody
+}
+
+type PostOauthIntrospectResponseObject interface {
+	VisitPostOauthIntrospectResponse(w http.ResponseWriter) error
+}
+
+type PostOauthIntrospect200JSONResponse IntrospectionResponse
+
+func (response PostOauthIntrospect200JSONResponse) VisitPostOauthIntrospectResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostOauthIntrospect400JSONResponse externalRef0.StandardErrorResponse
+
+func (response PostOauthIntrospect400JSONResponse) VisitPostOauthIntrospectResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostOauthIntrospect401JSONResponse externalRef0.StandardErrorResponse
+
+func (response PostOauthIntrospect401JSONResponse) VisitPostOauthIntrospectResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostOauthIntrospect500JSONResponse externalRef0.StandardErrorResponse
+
+func (response PostOauthIntrospect500JSONResponse) VisitPostOauthIntrospectResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostOauthRevokeRequestObject struct {
+	Body *PostOauthRevokeFormdataRequestBody
+}
+
+type PostOauthRevokeResponseObject interface {
+	VisitPostOauthRevokeResponse(w http.ResponseWriter) error
+}
+
+type PostOauthRevoke200Response struct {
+}
+
+func (response PostOauthRevoke200Response) VisitPostOauthRevokeResponse(w http.ResponseWriter) error {
+	w.WriteHeader(200)
+	return nil
+}
+
+type PostOauthRevoke400JSONResponse externalRef0.StandardErrorResponse
+
+func (response PostOauthRevoke400JSONResponse) VisitPostOauthRevokeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostOauthRevoke401JSONResponse externalRef0.StandardErrorResponse
+
+func (response PostOauthRevoke401JSONResponse) VisitPostOauthRevokeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostOauthRevoke500JSONResponse externalRef0.StandardErrorResponse
+
+func (response PostOauthRevoke500JSONResponse) VisitPostOauthRevokeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostOauthTokenRequestObject struct {
+	Body *PostOauthTokenFormdataRequestBody
+}
+
+type PostOauthTokenResponseObject interface {
+	VisitPostOauthTokenResponse(w http.ResponseWriter) error
+}
+
+type PostOauthToken200ResponseHeaders struct {
+	CacheControl string
+}
+
+type PostOauthToken200JSONResponse struct {
+	Body    ClientCredentialsResponse
+	Headers PostOauthToken200ResponseHeaders
+}
+
+func (response PostOauthToken200JSONResponse) VisitPostOauthTokenResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", fmt.Sprint(response.Headers.CacheControl))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostOauthToken400JSONResponse externalRef0.StandardErrorResponse
+
+func (response PostOauthToken400JSONResponse) VisitPostOauthTokenResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostOauthToken401JSONResponse externalRef0.StandardErrorResponse
+
+func (response PostOauthToken401JSONResponse) VisitPostOauthTokenResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostOauthToken500JSONResponse externalRef0.StandardErrorResponse
+
+func (response PostOauthToken500JSONResponse) VisitPostOauthTokenResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetOauthProviderCallbackRequestObject struct {
+	Provider string `json:"provider"`
+	Params   GetOauthProviderCallbackParams
+}
+
+type GetOauthProviderCallbackResponseObject interface {
+	VisitGetOauthProviderCallbackResponse(w http.ResponseWriter) error
+}
+
+type GetOauthProviderCallback200JSONResponse LoginResponse
+
+func (response GetOauthProviderCallback200JSONResponse) VisitGetOauthProviderCallbackResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetOauthProviderCallback400JSONResponse externalRef0.StandardErrorResponse
+
+func (response GetOauthProviderCallback400JSONResponse) VisitGetOauthProviderCallbackResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetOauthProviderCallback404JSONResponse externalRef0.StandardErrorResponse
+
+func (response GetOauthProviderCallback404JSONResponse) VisitGetOauthProviderCallbackResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetOauthProviderCallback409JSONResponse externalRef0.StandardErrorResponse
+
+func (response GetOauthProviderCallback409JSONResponse) VisitGetOauthProviderCallbackResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetOauthProviderCallback500JSONResponse externalRef0.StandardErrorResponse
+
+func (response GetOauthProviderCallback500JSONResponse) VisitGetOauthProviderCallbackResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetOauthProviderCallback502JSONResponse externalRef0.StandardErrorResponse
+
+func (response GetOauthProviderCallback502JSONResponse) VisitGetOauthProviderCallbackResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(502)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetOauthProviderStartRequestObject struct {
+	Provider string `json:"provider"`
+}
+
+type GetOauthProviderStartResponseObject interface {
+	VisitGetOauthProviderStartResponse(w http.ResponseWriter) error
+}
+
+type GetOauthProviderStart302ResponseHeaders struct {
+	Location string
+}
+
+type GetOauthProviderStart302Response struct {
+	Headers GetOauthProviderStart302ResponseHeaders
+}
+
+func (response GetOauthProviderStart302Response) VisitGetOauthProviderStartResponse(w http.ResponseWriter) error {
+	w.Header().Set("Location", fmt.Sprint(response.Headers.Location))
+	w.WriteHeader(302)
+	return nil
+}
+
+type GetOauthProviderStart404JSONResponse externalRef0.StandardErrorResponse
+
+func (response GetOauthProviderStart404JSONResponse) VisitGetOauthProviderStartResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetOauthProviderStart500JSONResponse externalRef0.StandardErrorResponse
+
+func (response GetOauthProviderStart500JSONResponse) VisitGetOauthProviderStartResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostPasswordChangeRequestObject struct {
+	Body *PostPasswordChangeJSONRequestBody
+}
+
+type PostPasswordChangeResponseObject interface {
+	VisitPostPasswordChangeResponse(w http.ResponseWriter) error
+}
+
+type PostPasswordChange200JSONResponse LogoutResponse
+
+func (response PostPasswordChange200JSONResponse) VisitPostPasswordChangeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostPasswordChange400JSONResponse externalRef0.StandardErrorResponse
+
+func (response PostPasswordChange400JSONResponse) VisitPostPasswordChangeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostPasswordChange401JSONResponse externalRef0.StandardErrorResponse
+
+func (response PostPasswordChange401JSONResponse) VisitPostPasswordChangeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostPasswordChange429ResponseHeaders struct {
+	RetryAfter int
+}
+
+type PostPasswordChange429JSONResponse struct {
+	Body    externalRef0.StandardErrorResponse
+	Headers PostPasswordChange429ResponseHeaders
+}
+
+func (response PostPasswordChange429JSONResponse) VisitPostPasswordChangeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostPasswordChange500JSONResponse externalRef0.StandardErrorResponse
+
+func (response PostPasswordChange500JSONResponse) VisitPostPasswordChangeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostPasswordForgotRequestObject struct {
+	Body *PostPasswordForgotJSONRequestBody
+}
+
+type PostPasswordForgotResponseObject interface {
+	VisitPostPasswordForgotResponse(w http.ResponseWriter) error
+}
+
+type PostPasswordForgot200JSONResponse externalRef0.BaseSuccessResponse
+
+func (response PostPasswordForgot200JSONResponse) VisitPostPasswordForgotResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostPasswordForgot500JSONResponse externalRef0.StandardErrorResponse
+
+func (response PostPasswordForgot500JSONResponse) VisitPostPasswordForgotResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostPasswordResetRequestObject struct {
+	Body *PostPasswordResetJSONRequestBody
+}
+
+type PostPasswordResetResponseObject interface {
+	VisitPostPasswordResetResponse(w http.ResponseWriter) error
+}
+
+type PostPasswordReset200JSONResponse externalRef0.BaseSuccessResponse
+
+func (response PostPasswordReset200JSONResponse) VisitPostPasswordResetResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostPasswordReset400JSONResponse externalRef0.StandardErrorResponse
+
+func (response PostPasswordReset400JSONResponse) VisitPostPasswordResetResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostPasswordReset500JSONResponse externalRef0.StandardErrorResponse
+
+func (response PostPasswordReset500JSONResponse) VisitPostPasswordResetResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostRefreshRequestObject struct {
+	Body *PostRefreshJSONRequestBody
+}
+
+type PostRefreshResponseObject interface {
+	VisitPostRefreshResponse(w http.ResponseWriter) error
+}
+
+type PostRefresh200JSONResponse RefreshResponse
+
+func (response PostRefresh200JSONResponse) VisitPostRefreshResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostRefresh401JSONResponse externalRef0.StandardErrorResponse
+
+func (response PostRefresh401JSONResponse) VisitPostRefreshResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostRefresh500JSONResponse externalRef0.StandardErrorResponse
+
+func (response PostRefresh500JSONResponse) VisitPostRefreshResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostRegisterRequestObject struct {
 	Body *PostRegisterJSONRequestBody
 }
 
-type PostRegisterResponseObject interface {
-	VisitPostRegisterResponse(w http.ResponseWriter) error
+type PostRegisterResponseObject interface {
+	VisitPostRegisterResponse(w http.ResponseWriter) error
+}
+
+type PostRegister201JSONResponse RegisterResponse
+
+func (response PostRegister201JSONResponse) VisitPostRegisterResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostRegister400JSONResponse externalRef0.StandardErrorResponse
+
+func (response PostRegister400JSONResponse) VisitPostRegisterResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostRegister500JSONResponse externalRef0.StandardErrorResponse
+
+func (response PostRegister500JSONResponse) VisitPostRegisterResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRolesRequestObject struct {
+}
+
+type GetRolesResponseObject interface {
+	VisitGetRolesResponse(w http.ResponseWriter) error
+}
+
+type GetRoles200JSONResponse RoleListResponse
+
+func (response GetRoles200JSONResponse) VisitGetRolesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRoles401JSONResponse externalRef0.StandardErrorResponse
+
+func (response GetRoles401JSONResponse) VisitGetRolesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRoles403JSONResponse externalRef0.StandardErrorResponse
+
+func (response GetRoles403JSONResponse) VisitGetRolesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRoles500JSONResponse externalRef0.StandardErrorResponse
+
+func (response GetRoles500JSONResponse) VisitGetRolesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSessionsRequestObject struct {
+}
+
+type GetSessionsResponseObject interface {
+	VisitGetSessionsResponse(w http.ResponseWriter) error
+}
+
+type GetSessions200JSONResponse SessionListResponse
+
+func (response GetSessions200JSONResponse) VisitGetSessionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSessions401JSONResponse externalRef0.StandardErrorResponse
+
+func (response GetSessions401JSONResponse) VisitGetSessionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSessions500JSONResponse externalRef0.StandardErrorResponse
+
+func (response GetSessions500JSONResponse) VisitGetSessionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteSessionsIdRequestObject struct {
+	Id string `json:"id"`
+}
+
+type DeleteSessionsIdResponseObject interface {
+	VisitDeleteSessionsIdResponse(w http.ResponseWriter) error
+}
+
+type DeleteSessionsId200JSONResponse LogoutResponse
+
+func (response DeleteSessionsId200JSONResponse) VisitDeleteSessionsIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteSessionsId401JSONResponse externalRef0.StandardErrorResponse
+
+func (response DeleteSessionsId401JSONResponse) VisitDeleteSessionsIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteSessionsId404JSONResponse externalRef0.StandardErrorResponse
+
+func (response DeleteSessionsId404JSONResponse) VisitDeleteSessionsIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteSessionsId500JSONResponse externalRef0.StandardErrorResponse
+
+func (response DeleteSessionsId500JSONResponse) VisitDeleteSessionsIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUserinfoRequestObject struct {
+}
+
+type GetUserinfoResponseObject interface {
+	VisitGetUserinfoResponse(w http.ResponseWriter) error
+}
+
+type GetUserinfo200JSONResponse UserInfoResponse
+
+func (response GetUserinfo200JSONResponse) VisitGetUserinfoResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUserinfo401JSONResponse externalRef0.StandardErrorResponse
+
+func (response GetUserinfo401JSONResponse) VisitGetUserinfoResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUserinfo500JSONResponse externalRef0.StandardErrorResponse
+
+func (response GetUserinfo500JSONResponse) VisitGetUserinfoResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUsersUserIdRolesRequestObject struct {
+	UserId int64 `json:"user_id"`
+}
+
+type GetUsersUserIdRolesResponseObject interface {
+	VisitGetUsersUserIdRolesResponse(w http.ResponseWriter) error
+}
+
+type GetUsersUserIdRoles200JSONResponse UserRolesResponse
+
+func (response GetUsersUserIdRoles200JSONResponse) VisitGetUsersUserIdRolesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUsersUserIdRoles401JSONResponse externalRef0.StandardErrorResponse
+
+func (response GetUsersUserIdRoles401JSONResponse) VisitGetUsersUserIdRolesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUsersUserIdRoles403JSONResponse externalRef0.StandardErrorResponse
+
+func (response GetUsersUserIdRoles403JSONResponse) VisitGetUsersUserIdRolesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUsersUserIdRoles404JSONResponse externalRef0.StandardErrorResponse
+
+func (response GetUsersUserIdRoles404JSONResponse) VisitGetUsersUserIdRolesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUsersUserIdRoles500JSONResponse externalRef0.StandardErrorResponse
+
+func (response GetUsersUserIdRoles500JSONResponse) VisitGetUsersUserIdRolesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteUsersUserIdRolesRoleRequestObject struct {
+	UserId int64  `json:"user_id"`
+	Role   string `json:"role"`
+}
+
+type DeleteUsersUserIdRolesRoleResponseObject interface {
+	VisitDeleteUsersUserIdRolesRoleResponse(w http.ResponseWriter) error
+}
+
+type DeleteUsersUserIdRolesRole200JSONResponse UserRolesResponse
+
+func (response DeleteUsersUserIdRolesRole200JSONResponse) VisitDeleteUsersUserIdRolesRoleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteUsersUserIdRolesRole401JSONResponse externalRef0.StandardErrorResponse
+
+func (response DeleteUsersUserIdRolesRole401JSONResponse) VisitDeleteUsersUserIdRolesRoleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteUsersUserIdRolesRole403JSONResponse externalRef0.StandardErrorResponse
+
+func (response DeleteUsersUserIdRolesRole403JSONResponse) VisitDeleteUsersUserIdRolesRoleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteUsersUserIdRolesRole404JSONResponse externalRef0.StandardErrorResponse
+
+func (response DeleteUsersUserIdRolesRole404JSONResponse) VisitDeleteUsersUserIdRolesRoleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteUsersUserIdRolesRole500JSONResponse externalRef0.StandardErrorResponse
+
+func (response DeleteUsersUserIdRolesRole500JSONResponse) VisitDeleteUsersUserIdRolesRoleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutUsersUserIdRolesRoleRequestObject struct {
+	UserId int64  `json:"user_id"`
+	Role   string `json:"role"`
+}
+
+type PutUsersUserIdRolesRoleResponseObject interface {
+	VisitPutUsersUserIdRolesRoleResponse(w http.ResponseWriter) error
+}
+
+type PutUsersUserIdRolesRole200JSONResponse UserRolesResponse
+
+func (response PutUsersUserIdRolesRole200JSONResponse) VisitPutUsersUserIdRolesRoleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutUsersUserIdRolesRole401JSONResponse externalRef0.StandardErrorResponse
+
+func (response PutUsersUserIdRolesRole401JSONResponse) VisitPutUsersUserIdRolesRoleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutUsersUserIdRolesRole403JSONResponse externalRef0.StandardErrorResponse
+
+func (response PutUsersUserIdRolesRole403JSONResponse) VisitPutUsersUserIdRolesRoleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutUsersUserIdRolesRole404JSONResponse externalRef0.StandardErrorResponse
+
+func (response PutUsersUserIdRolesRole404JSONResponse) VisitPutUsersUserIdRolesRoleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutUsersUserIdRolesRole500JSONResponse externalRef0.StandardErrorResponse
+
+func (response PutUsersUserIdRolesRole500JSONResponse) VisitPutUsersUserIdRolesRoleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+// StrictServerInterface represents all server handlers.
+type StrictServerInterface interface {
+	// list api keys of current user
+	// (GET /api-keys)
+	GetApiKeys(ctx context.Context, request GetApiKeysRequestObject) (GetApiKeysResponseObject, error)
+	// create api key for machine clients acting as current user
+	// (POST /api-keys)
+	PostApiKeys(ctx context.Context, request PostApiKeysRequestObject) (PostApiKeysResponseObject, error)
+	// revoke an api key of current user
+	// (DELETE /api-keys/{id})
+	DeleteApiKeysId(ctx context.Context, request DeleteApiKeysIdRequestObject) (DeleteApiKeysIdResponseObject, error)
+	// verify email address with the link sent on registration
+	// (GET /email/verify)
+	GetEmailVerify(ctx context.Context, request GetEmailVerifyRequestObject) (GetEmailVerifyResponseObject, error)
+	// resend email verification link
+	// (POST /email/verify/resend)
+	PostEmailVerifyResend(ctx context.Context, request PostEmailVerifyResendRequestObject) (PostEmailVerifyResendResponseObject, error)
+	// issue short-lived token acting as another user, admin only
+	// (POST /impersonate)
+	PostImpersonate(ctx context.Context, request PostImpersonateRequestObject) (PostImpersonateResponseObject, error)
+	// login with credentials
+	// (POST /login)
+	PostLogin(ctx context.Context, request PostLoginRequestObject) (PostLoginResponseObject, error)
+	// complete login with second factor
+	// (POST /login/mfa)
+	PostLoginMfa(ctx context.Context, request PostLoginMfaRequestObject) (PostLoginMfaResponseObject, error)
+	// revoke current session
+	// (POST /logout)
+	PostLogout(ctx context.Context, request PostLogoutRequestObject) (PostLogoutResponseObject, error)
+	// revoke every session of current user
+	// (POST /logout-all)
+	PostLogoutAll(ctx context.Context, request PostLogoutAllRequestObject) (PostLogoutAllResponseObject, error)
+	// request passwordless login link by email
+	// (POST /magic-link/request)
+	PostMagicLinkRequest(ctx context.Context, request PostMagicLinkRequestRequestObject) (PostMagicLinkRequestResponseObject, error)
+	// login with token of a login link, from the browser that requested it
+	// (POST /magic-link/verify)
+	PostMagicLinkVerify(ctx context.Context, request PostMagicLinkVerifyRequestObject) (PostMagicLinkVerifyResponseObject, error)
+	// confirm totp enrollment with a code from the authenticator app
+	// (POST /mfa/totp/confirm)
+	PostMfaTotpConfirm(ctx context.Context, request PostMfaTotpConfirmRequestObject) (PostMfaTotpConfirmResponseObject, error)
+	// start totp enrollment for current user
+	// (POST /mfa/totp/setup)
+	PostMfaTotpSetup(ctx context.Context, request PostMfaTotpSetupRequestObject) (PostMfaTotpSetupResponseObject, error)
+	// RFC 7662 introspection of an access or refresh token, for registered clients
+	// (POST /oauth/introspect)
+	PostOauthIntrospect(ctx context.Context, request PostOauthIntrospectRequestObject) (PostOauthIntrospectResponseObject, error)
+	// RFC 7009 revocation of an access or refresh token, for registered clients
+	// (POST /oauth/revoke)
+	PostOauthRevoke(ctx context.Context, request PostOauthRevokeRequestObject) (PostOauthRevokeResponseObject, error)
+	// RFC 6749 client_credentials grant, token of a registered client acting as itself
+	// (POST /oauth/token)
+	PostOauthToken(ctx context.Context, request PostOauthTokenRequestObject) (PostOauthTokenResponseObject, error)
+	// complete social login with authorization code returned by provider
+	// (GET /oauth/{provider}/callback)
+	GetOauthProviderCallback(ctx context.Context, request GetOauthProviderCallbackRequestObject) (GetOauthProviderCallbackResponseObject, error)
+	// redirect to provider to start social login
+	// (GET /oauth/{provider}/start)
+	GetOauthProviderStart(ctx context.Context, request GetOauthProviderStartRequestObject) (GetOauthProviderStartResponseObject, error)
+	// change password of current user
+	// (POST /password/change)
+	PostPasswordChange(ctx context.Context, request PostPasswordChangeRequestObject) (PostPasswordChangeResponseObject, error)
+	// request password reset link by email
+	// (POST /password/forgot)
+	PostPasswordForgot(ctx context.Context, request PostPasswordForgotRequestObject) (PostPasswordForgotResponseObject, error)
+	// reset password with reset token
+	// (POST /password/reset)
+	PostPasswordReset(ctx context.Context, request PostPasswordResetRequestObject) (PostPasswordResetResponseObject, error)
+	// login with credentials
+	// (POST /refresh)
+	PostRefresh(ctx context.Context, request PostRefreshRequestObject) (PostRefreshResponseObject, error)
+	// register new user body request
+	// (POST /register)
+	PostRegister(ctx context.Context, request PostRegisterRequestObject) (PostRegisterResponseObject, error)
+	// list roles and their permissions, requires roles:read
+	// (GET /roles)
+	GetRoles(ctx context.Context, request GetRolesRequestObject) (GetRolesResponseObject, error)
+	// list active sessions of current user
+	// (GET /sessions)
+	GetSessions(ctx context.Context, request GetSessionsRequestObject) (GetSessionsResponseObject, error)
+	// revoke a session of current user
+	// (DELETE /sessions/{id})
+	DeleteSessionsId(ctx context.Context, request DeleteSessionsIdRequestObject) (DeleteSessionsIdResponseObject, error)
+	// OpenID Connect claims of the user the bearer token belongs to
+	// (GET /userinfo)
+	GetUserinfo(ctx context.Context, request GetUserinfoRequestObject) (GetUserinfoResponseObject, error)
+	// roles and permissions of a user, requires roles:read
+	// (GET /users/{user_id}/roles)
+	GetUsersUserIdRoles(ctx context.Context, request GetUsersUserIdRolesRequestObject) (GetUsersUserIdRolesResponseObject, error)
+	// revoke a role of a user, requires roles:manage. applies to tokens issued after the change
+	// (DELETE /users/{user_id}/roles/{role})
+	DeleteUsersUserIdRolesRole(ctx context.Context, request DeleteUsersUserIdRolesRoleRequestObject) (DeleteUsersUserIdRolesRoleResponseObject, error)
+	// grant a role to a user, requires roles:manage. applies to tokens issued after the change
+	// (PUT /users/{user_id}/roles/{role})
+	PutUsersUserIdRolesRole(ctx context.Context, request PutUsersUserIdRolesRoleRequestObject) (PutUsersUserIdRolesRoleResponseObject, error)
+}
+
+type StrictHandlerFunc = strictgin.StrictGinHandlerFunc
+type StrictMiddlewareFunc = strictgin.StrictGinMiddlewareFunc
+
+func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares}
+}
+
+type strictHandler struct {
+	ssi         StrictServerInterface
+	middlewares []StrictMiddlewareFunc
+}
+
+// GetApiKeys operation middleware
+func (sh *strictHandler) GetApiKeys(ctx *gin.Context) {
+	var request GetApiKeysRequestObject
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetApiKeys(ctx, request.(GetApiKeysRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetApiKeys")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetApiKeysResponseObject); ok {
+		if err := validResponse.VisitGetApiKeysResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostApiKeys operation middleware
+func (sh *strictHandler) PostApiKeys(ctx *gin.Context) {
+	var request PostApiKeysRequestObject
+
+	var body PostApiKeysJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostApiKeys(ctx, request.(PostApiKeysRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostApiKeys")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PostApiKeysResponseObject); ok {
+		if err := validResponse.VisitPostApiKeysResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteApiKeysId operation middleware
+func (sh *strictHandler) DeleteApiKeysId(ctx *gin.Context, id string) {
+	var request DeleteApiKeysIdRequestObject
+
+	request.Id = id
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteApiKeysId(ctx, request.(DeleteApiKeysIdRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteApiKeysId")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(DeleteApiKeysIdResponseObject); ok {
+		if err := validResponse.VisitDeleteApiKeysIdResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetEmailVerify operation middleware
+func (sh *strictHandler) GetEmailVerify(ctx *gin.Context, params GetEmailVerifyParams) {
+	var request GetEmailVerifyRequestObject
+
+	request.Params = params
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetEmailVerify(ctx, request.(GetEmailVerifyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetEmailVerify")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetEmailVerifyResponseObject); ok {
+		if err := validResponse.VisitGetEmailVerifyResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostEmailVerifyResend operation middleware
+func (sh *strictHandler) PostEmailVerifyResend(ctx *gin.Context) {
+	var request PostEmailVerifyResendRequestObject
+
+	var body PostEmailVerifyResendJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostEmailVerifyResend(ctx, request.(PostEmailVerifyResendRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostEmailVerifyResend")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PostEmailVerifyResendResponseObject); ok {
+		if err := validResponse.VisitPostEmailVerifyResendResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostImpersonate operation middleware
+func (sh *strictHandler) PostImpersonate(ctx *gin.Context) {
+	var request PostImpersonateRequestObject
+
+	var body PostImpersonateJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostImpersonate(ctx, request.(PostImpersonateRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostImpersonate")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PostImpersonateResponseObject); ok {
+		if err := validResponse.VisitPostImpersonateResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostLogin operation middleware
+func (sh *strictHandler) PostLogin(ctx *gin.Context) {
+	var request PostLoginRequestObject
+
+	var body PostLoginJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostLogin(ctx, request.(PostLoginRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostLogin")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PostLoginResponseObject); ok {
+		if err := validResponse.VisitPostLoginResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostLoginMfa operation middleware
+func (sh *strictHandler) PostLoginMfa(ctx *gin.Context) {
+	var request PostLoginMfaRequestObject
+
+	var body PostLoginMfaJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostLoginMfa(ctx, request.(PostLoginMfaRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostLoginMfa")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PostLoginMfaResponseObject); ok {
+		if err := validResponse.VisitPostLoginMfaResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostLogout operation middleware
+func (sh *strictHandler) PostLogout(ctx *gin.Context) {
+	var request PostLogoutRequestObject
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostLogout(ctx, request.(PostLogoutRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostLogout")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PostLogoutResponseObject); ok {
+		if err := validResponse.VisitPostLogoutResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostLogoutAll operation middleware
+func (sh *strictHandler) PostLogoutAll(ctx *gin.Context) {
+	var request PostLogoutAllRequestObject
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostLogoutAll(ctx, request.(PostLogoutAllRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostLogoutAll")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PostLogoutAllResponseObject); ok {
+		if err := validResponse.VisitPostLogoutAllResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostMagicLinkRequest operation middleware
+func (sh *strictHandler) PostMagicLinkRequest(ctx *gin.Context) {
+	var request PostMagicLinkRequestRequestObject
+
+	var body PostMagicLinkRequestJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostMagicLinkRequest(ctx, request.(PostMagicLinkRequestRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostMagicLinkRequest")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PostMagicLinkRequestResponseObject); ok {
+		if err := validResponse.VisitPostMagicLinkRequestResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostMagicLinkVerify operation middleware
+func (sh *strictHandler) PostMagicLinkVerify(ctx *gin.Context) {
+	var request PostMagicLinkVerifyRequestObject
+
+	var body PostMagicLinkVerifyJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostMagicLinkVerify(ctx, request.(PostMagicLinkVerifyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostMagicLinkVerify")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PostMagicLinkVerifyResponseObject); ok {
+		if err := validResponse.VisitPostMagicLinkVerifyResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostMfaTotpConfirm operation middleware
+func (sh *strictHandler) PostMfaTotpConfirm(ctx *gin.Context) {
+	var request PostMfaTotpConfirmRequestObject
+
+	var body PostMfaTotpConfirmJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostMfaTotpConfirm(ctx, request.(PostMfaTotpConfirmRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostMfaTotpConfirm")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PostMfaTotpConfirmResponseObject); ok {
+		if err := validResponse.VisitPostMfaTotpConfirmResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostMfaTotpSetup operation middleware
+func (sh *strictHandler) PostMfaTotpSetup(ctx *gin.Context) {
+	var request PostMfaTotpSetupRequestObject
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostMfaTotpSetup(ctx, request.(PostMfaTotpSetupRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostMfaTotpSetup")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PostMfaTotpSetupResponseObject); ok {
+		if err := validResponse.VisitPostMfaTotpSetupResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostOauthIntrospect operation middleware
+func (sh *strictHandler) PostOauthIntrospect(ctx *gin.Context) {
+	var request PostOauthIntrospectRequestObject
+
+	if err := ctx.Request.ParseForm(); err != nil {
+		ctx.Error(err)
+		return
+	}
+	var body PostOauthIntrospectFormdataRequestBody
+	if err := runtime.BindForm(&body, ctx.Request.Form, nil, nil); err != nil {
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostOauthIntrospect(ctx, request.(PostOauthIntrospectRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostOauthIntrospect")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PostOauthIntrospectResponseObject); ok {
+		if err := validResponse.VisitPostOauthIntrospectResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostOauthRevoke operation middleware
+func (sh *strictHandler) PostOauthRevoke(ctx *gin.Context) {
+	var request PostOauthRevokeRequestObject
+
+	if err := ctx.Request.ParseForm(); err != nil {
+		ctx.Error(err)
+		return
+	}
+	var body PostOauthRevokeFormdataRequestBody
+	if err := runtime.BindForm(&body, ctx.Request.Form, nil, nil); err != nil {
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostOauthRevoke(ctx, request.(PostOauthRevokeRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostOauthRevoke")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PostOauthRevokeResponseObject); ok {
+		if err := validResponse.VisitPostOauthRevokeResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostOauthToken operation middleware
+func (sh *strictHandler) PostOauthToken(ctx *gin.Context) {
+	var request PostOauthTokenRequestObject
+
+	if err := ctx.Request.ParseForm(); err != nil {
+		ctx.Error(err)
+		return
+	}
+	var body PostOauthTokenFormdataRequestBody
+	if err := runtime.BindForm(&body, ctx.Request.Form, nil, nil); err != nil {
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostOauthToken(ctx, request.(PostOauthTokenRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostOauthToken")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PostOauthTokenResponseObject); ok {
+		if err := validResponse.VisitPostOauthTokenResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type PostRegister201JSONResponse RegisterResponse
+// GetOauthProviderCallback operation middleware
+func (sh *strictHandler) GetOauthProviderCallback(ctx *gin.Context, provider string, params GetOauthProviderCallbackParams) {
+	var request GetOauthProviderCallbackRequestObject
 
-func (response PostRegister201JSONResponse) VisitPostRegisterResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(201)
+	request.Provider = provider
+	request.Params = params
 
-	return json.NewEncoder(w).Encode(response)
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetOauthProviderCallback(ctx, request.(GetOauthProviderCallbackRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetOauthProviderCallback")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetOauthProviderCallbackResponseObject); ok {
+		if err := validResponse.VisitGetOauthProviderCallbackResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type PostRegister400JSONResponse externalRef0.StandardErrorResponse
+// GetOauthProviderStart operation middleware
+func (sh *strictHandler) GetOauthProviderStart(ctx *gin.Context, provider string) {
+	var request GetOauthProviderStartRequestObject
 
-func (response PostRegister400JSONResponse) VisitPostRegisterResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	request.Provider = provider
 
-	return json.NewEncoder(w).Encode(response)
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetOauthProviderStart(ctx, request.(GetOauthProviderStartRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetOauthProviderStart")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetOauthProviderStartResponseObject); ok {
+		if err := validResponse.VisitGetOauthProviderStartResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type PostRegister500JSONResponse externalRef0.StandardErrorResponse
+// PostPasswordChange operation middleware
+func (sh *strictHandler) PostPasswordChange(ctx *gin.Context) {
+	var request PostPasswordChangeRequestObject
 
-func (response PostRegister500JSONResponse) VisitPostRegisterResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	var body PostPasswordChangeJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
 
-	return json.NewEncoder(w).Encode(response)
-}
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostPasswordChange(ctx, request.(PostPasswordChangeRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostPasswordChange")
+	}
 
-// StrictServerInterface represents all server handlers.
-type StrictServerInterface interface {
-	// login with credentials
-	// (POST /login)
-	PostLogin(ctx context.Context, request PostLoginRequestObject) (PostLoginResponseObject, error)
-	// login with credentials
-	// (POST /refresh)
-	PostRefresh(ctx context.Context, request PostRefreshRequestObject) (PostRefreshResponseObject, error)
-	// register new user body request
-	// (POST /register)
-	PostRegister(ctx context.Context, request PostRegisterRequestObject) (PostRegisterResponseObject, error)
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PostPasswordChangeResponseObject); ok {
+		if err := validResponse.VisitPostPasswordChangeResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type StrictHandlerFunc = strictgin.StrictGinHandlerFunc
-type StrictMiddlewareFunc = strictgin.StrictGinMiddlewareFunc
+// PostPasswordForgot operation middleware
+func (sh *strictHandler) PostPasswordForgot(ctx *gin.Context) {
+	var request PostPasswordForgotRequestObject
 
-func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
-	return &strictHandler{ssi: ssi, middlewares: middlewares}
-}
+	var body PostPasswordForgotJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
 
-type strictHandler struct {
-	ssi         StrictServerInterface
-	middlewares []StrictMiddlewareFunc
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostPasswordForgot(ctx, request.(PostPasswordForgotRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostPasswordForgot")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PostPasswordForgotResponseObject); ok {
+		if err := validResponse.VisitPostPasswordForgotResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-// PostLogin operation middleware
-func (sh *strictHandler) PostLogin(ctx *gin.Context) {
-	var request PostLoginRequestObject
+// PostPasswordReset operation middleware
+func (sh *strictHandler) PostPasswordReset(ctx *gin.Context) {
+	var request PostPasswordResetRequestObject
 
-	var body PostLoginJSONRequestBody
+	var body PostPasswordResetJSONRequestBody
 	if err := ctx.ShouldBindJSON(&body); err != nil {
 		ctx.Status(http.StatusBadRequest)
 		ctx.Error(err)
@@ -323,10 +3282,10 @@ func (sh *strictHandler) PostLogin(ctx *gin.Context) {
 	request.Body = &body
 
 	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
-		return sh.ssi.PostLogin(ctx, request.(PostLoginRequestObject))
+		return sh.ssi.PostPasswordReset(ctx, request.(PostPasswordResetRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "PostLogin")
+		handler = middleware(handler, "PostPasswordReset")
 	}
 
 	response, err := handler(ctx, request)
@@ -334,8 +3293,8 @@ func (sh *strictHandler) PostLogin(ctx *gin.Context) {
 	if err != nil {
 		ctx.Error(err)
 		ctx.Status(http.StatusInternalServerError)
-	} else if validResponse, ok := response.(PostLoginResponseObject); ok {
-		if err := validResponse.VisitPostLoginResponse(ctx.Writer); err != nil {
+	} else if validResponse, ok := response.(PostPasswordResetResponseObject); ok {
+		if err := validResponse.VisitPostPasswordResetResponse(ctx.Writer); err != nil {
 			ctx.Error(err)
 		}
 	} else if response != nil {
@@ -409,22 +3368,266 @@ func (sh *strictHandler) PostRegister(ctx *gin.Context) {
 	}
 }
 
+// GetRoles operation middleware
+func (sh *strictHandler) GetRoles(ctx *gin.Context) {
+	var request GetRolesRequestObject
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetRoles(ctx, request.(GetRolesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetRoles")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetRolesResponseObject); ok {
+		if err := validResponse.VisitGetRolesResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetSessions operation middleware
+func (sh *strictHandler) GetSessions(ctx *gin.Context) {
+	var request GetSessionsRequestObject
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetSessions(ctx, request.(GetSessionsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetSessions")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetSessionsResponseObject); ok {
+		if err := validResponse.VisitGetSessionsResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteSessionsId operation middleware
+func (sh *strictHandler) DeleteSessionsId(ctx *gin.Context, id string) {
+	var request DeleteSessionsIdRequestObject
+
+	request.Id = id
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteSessionsId(ctx, request.(DeleteSessionsIdRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteSessionsId")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(DeleteSessionsIdResponseObject); ok {
+		if err := validResponse.VisitDeleteSessionsIdResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetUserinfo operation middleware
+func (sh *strictHandler) GetUserinfo(ctx *gin.Context) {
+	var request GetUserinfoRequestObject
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetUserinfo(ctx, request.(GetUserinfoRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetUserinfo")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetUserinfoResponseObject); ok {
+		if err := validResponse.VisitGetUserinfoResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetUsersUserIdRoles operation middleware
+func (sh *strictHandler) GetUsersUserIdRoles(ctx *gin.Context, userId int64) {
+	var request GetUsersUserIdRolesRequestObject
+
+	request.UserId = userId
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetUsersUserIdRoles(ctx, request.(GetUsersUserIdRolesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetUsersUserIdRoles")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetUsersUserIdRolesResponseObject); ok {
+		if err := validResponse.VisitGetUsersUserIdRolesResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteUsersUserIdRolesRole operation middleware
+func (sh *strictHandler) DeleteUsersUserIdRolesRole(ctx *gin.Context, userId int64, role string) {
+	var request DeleteUsersUserIdRolesRoleRequestObject
+
+	request.UserId = userId
+	request.Role = role
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteUsersUserIdRolesRole(ctx, request.(DeleteUsersUserIdRolesRoleRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteUsersUserIdRolesRole")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(DeleteUsersUserIdRolesRoleResponseObject); ok {
+		if err := validResponse.VisitDeleteUsersUserIdRolesRoleResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PutUsersUserIdRolesRole operation middleware
+func (sh *strictHandler) PutUsersUserIdRolesRole(ctx *gin.Context, userId int64, role string) {
+	var request PutUsersUserIdRolesRoleRequestObject
+
+	request.UserId = userId
+	request.Role = role
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PutUsersUserIdRolesRole(ctx, request.(PutUsersUserIdRolesRoleRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PutUsersUserIdRolesRole")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PutUsersUserIdRolesRoleResponseObject); ok {
+		if err := validResponse.VisitPutUsersUserIdRolesRoleResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/9xWT0/jOhD/KpHfO1akfY+95AbSHpBWWgRHhKIhnrZmEzvMTKgqlO++spPSP7gtXQrL",
-	"7s3yjOfPb37jmSdVuKp2Fq2wyp4UF1OsIBy/Ejm6Qq6dZfQXNbkaSQwGMXqxP8i8RpUpFjJ2otpBJ8kL",
-	"pzEqrpAZJnEZC0jDm2+NFZwgeQUhKGJP24EifGgMoVbZzbOPdYt9aGph5nawMOPu7rEQ7+Cbmxh7hQ8N",
-	"skSSrsCU0chrYJ450vtj62ysvNgRxhJ8KMvvY5XdPKl/CccqU/+ky8qlfdlSrrHgHGqTF66qnM2pN5Gf",
-	"A+N1UxTI/Gy2HWzmp0HgkKwJx4Q8zcX9QBvV2CZpPSwbad+2A3XVWdxagX0e2wiYzzY/Gs43wLMFnIlh",
-	"QfoFfo4NseQWqnjjlbBLeji5V9ytGt/D+mV+n5v47Wba4flttGaHRfYihDf8lq/6E2Nl2B7ytYDVQHrP",
-	"bNAoYErePh2CCLQ2YpyF8nL1dayDjWUBW+yCYcu8MBWyQFXHu89IGbcZBkRudFwYLl7Rs20IfeyCcudL",
-	"nTUyRSumAJ97cnZ5oQbqEYmNsypTo5PhydA7cTVaqI3K1P/hyreOTEOaaemnQ4Dddb+Ahy/Yu9AqU5eO",
-	"JQwQ1TEAWc6dnnvFwllBG95AXZd9FOk9O7sc//60q9nWZuRGLwg1GC46boR4/xsOj+170cjeuUYuyNTS",
-	"ARiwSWZGpklBqNljeTocHS2AdepHAgifRuIoWXx1yYxcR5wvRwTi0CaNROr7hCyUSbcWeQVuqgpo/hJH",
-	"T1koOSil/VzbzcB+6r4TBzf2hA9m4eZG8Rt4eJT6P0JpdNKXM+mWkb+Kp90+sY+ovdZ7MXV9aXsVVUfv",
-	"4H47wAucEouzpGGkhJarkjr9XGy4A8/YHss/i6svcb5zer7MJmgj+W0gbLwNlSpTKdQmfRyl0MjU75Q/",
-	"AwAA//9hlqtqtA8AAA==",
+	"H4sIAAAAAAAC/+xdW3PbOJb+KyjuPtKRO53NVPst4+7e8k56k7Ize6mulAoiDyW0SYANgFY0Lv33KRyA",
+	"JEiBusSWTXn80u2IF9y+852DcwHvo0QUpeDAtYou7iOVLKCg+OeHkv0NVuavUooSpGaAvycSqIZ0SrX5",
+	"VyZkYf6KUqrhTLMCojjSqxKii0hpyfg8WscRfCuZBHXQMyw19278nFOlp5U6sAOcFhB8XSkhY9/MpRRU",
+	"IlmpmeDRRZQxqTRJFlTSRINURGREL4DcwiomWhAJiZhz9g8gTIcaVIko7XwxDYUKtu1+oFLSVbRex5GE",
+	"PysmIY0ufjfDd91uOtm89WvzrJj9AYk2L7PL9ZEpfQ2qFFzheGmef8qii9/vo3+XkEUX0b9N2gWfuNWe",
+	"qBISNaUlmyaiKASfSveK6V+pgpsqSUCp5rXruA+JlGraGeq2xhyudo0f37k50K/rOLpcUD6Hz1SppZDp",
+	"NfxZgdIBoFZSAtfT0t0YXAMOy+03SLgTtzAVegFyqkApJrgdNGS0ynV0oWUFcQ8+9ilC85zUzxD4lkCp",
+	"EUWua0RwD60zIXKgfGMmNsbR63UIDZc5A64vJaTANaO5GpyluaRcT+0L+kIgeL4iCb5qmrTvIkwRVZWl",
+	"kBrSQfBvvk6VNAGioKTSMEhM3BQqI1BwB3JF8Mla1mzLmw305scbwZ5T0cpHdy4oAn2qxS3wIBhqHmO8",
+	"Qz2M6/fv2n4yrmEOsjMTm8JvGmnmffsIO/3qPNrpUnD0yNZW5gYx0KXn7qLdwopwszjE3UWWC+BEFEzb",
+	"1X8gAT+YKPHNu4f+XJzY/dW879Zq1f040t3cEyRIJGirixTwlFBF/u/sw+ers7/BiiyApiB3io15c9x0",
+	"aHMC9+fjX6QUcliqwFwOi5O5Mk1EGoZGAUrRefia0lRXqv+sJ3la0mQP0arb6L7RdS2qXxOC169CzoXe",
+	"qYWgoCzf3Q97W6idq6IEqQSnGgYbkUCV4JtAWS5WNVMTDpAi09JEG8AYhq0UyJjcGrXEOP5Cq5RpoqXp",
+	"TUCMzQNTa5ntZL/eCOtH47q3Owc7DpEdWsDvs2qHdcsDprZWDF6HYm++h7C1v4RfcS2FmV6Dq236U7M7",
+	"X+gakyaOaBW2r5x5MWDtw7dyT03LqN73ThXWMn9oFvydz7I93yxFfpgq22YgqIEpUdVsD4MCeFUETAcJ",
+	"mQS1cP/+OiDhA8p6wyrB1Q6J8UcxZ/xgSoyjLZZ4kC+9J7Z0Y+w8UmR0ClyKPC+MKLTD3NT7uJUw9nfO",
+	"CqYhNXyuhS5J+zypuGY5KTJq7gNOZ7lvpXsiadodbqyeWWIgVKK9buYqBw0kNxNLqCYT/GtiGlsyvTCt",
+	"tmZqsMFhAuxicxDigSvr9TpIWx/FXFR6LACwe8K0s4ncIPaBkfxG5yz5yPjt8eyMpon/Acmy4c3C4CKE",
+	"dFKwoYxu54faqOviEWFuLhEh0f2CG0ZnrgVlas+e+pjF14U6fe0avBQpqPEgynYKjdaH7KF6L3qIqXBt",
+	"pXiLqbpdytfByXfvfOppfwAlDUzOnCkN8jtUIzokp4Pb6Jxuu3q4XvWa81++Q+G24xu3zl0fBGgFPEVS",
+	"ZAm1BvCxONi0tXtLudNneRBF7+FNvBZ5wNrv0PP9IT53kAVrdeBD/D7dlw31/QS84jjFD/CJ31ijYnTh",
+	"GlZOaZpKGNhzMTV17u3wrpGpKWtcAizk4qht4iVVRJTAISWzFaGc0LQwNmqiGZ/7/o6gZWouTOm8248B",
+	"scE9tdfxEOrccpwA8GrgPAB7XwyPHGwv+jvW6YJx/YBt69625xehy0vBMyaLnebn9jYGLUXTxA3oqhyL",
+	"EhS6pJVeTCsZ9m9Yb/LuEbv74s4LH2Iq/l2BvOKZ2OI6Hva9mSvTO9TKkIbJI6MFy1fDVtGc3QEfvjx4",
+	"4XvcPEGnTX+Cq1njrdsYYd1sCHJmIq/rTnVn8Dt17XcN8nGcw9jubr3ejPn5xGxbG+2SHCAQh3VsY60f",
+	"EC/ZKyoSWobhLt9oylMq0x3RoRQ0Zbkajg/hJZqmzCh8mn/2nw5tFhlXmvJk2zQMRIxYAUrTogw+qZnO",
+	"w+/EENGQA3s4wLtJmwqSSjK9ujEYsrMzo4olHyq92DR8Gsc5oTyto/QuMigyQol0mzFIiTB83cbTEaRI",
+	"lub1rXQutC5Np2dAJci6WfuvX2uJ/q///dJ5BV7tv2ONC5EJHLqduci8D7h2myjy4fNVFEd3IK3dGv3w",
+	"5vzNuWldlMBpyaKL6Ef8yew59QKnY0JLdnYLK/zH3CotAwd841UaXUT/CdoGTxWaDRZ1ePvb83Or3bl2",
+	"Zh4ty9z1ZvKHi59Z+d0vPtux7nDI3RWiJTMxWhWj7dkGbY1f1obUJehKGpOVZhokQVvdGWPvzn94tO4e",
+	"KqSBsSAX87nxvTF+R3OWErvyxBpb6zj6j/PzMfXYSLbkNCc2lOsLGGoIH+S/f11/NUq6KKhcRRdRzpQm",
+	"9fIZaaoTdnADYbawQgXA91moDvrQwPyrSFePNi+hdI6eM0PLCtYb2P/hSF3YiX7iNqBxnT5n0I+JRWoh",
+	"lpwInoDF+8jQY0GOW+HVq0A+s0BaENUiSTIhSUGTBeN1lpjydvpdYV3HrdqY3LN0bVVpDho2Jfhn/N3J",
+	"8FWKukfSAjRIhZ0MY9y6BGzUTC/qxM0L6yroimbsTXjfKPh6RJV1oNE7LM8ujHWaIvHu/N2YelzPKRea",
+	"ZKLi6QsT2zoNljeSK7KAeOJWd4Jb3dU2y+4Xc58NT25KJorfnxXIVSt/rf/oRYggzhNx/lTSuAbGqz1F",
+	"nTWaut462/+UVFQDZotP0l0EzHowlk3O+C1RNqnbbb2kM+b7EJ9IDCnhXnjQjvSwbiNQR7Ioh8Nbe9mV",
+	"I5KODsJwOZiyK4IZy2aRaJKIimtzoQSeGmXhP3VqiLQ4cojcGL4FXhs+ge2A81IvjwS1QCbrE2MslF4a",
+	"mnQ/5GTJKkZJF5UmLhbh2xTnY7SCXJbtiZppP46pxwnNc5DothHaBhZj031N5RysIUMSys3FGRBP4tIR",
+	"2px+p1+q3cmUqsA4GKQ+y9kd5knegh8NphwrulwGvI0VG6+EJU3MatxOl5jCdiSi7KTHPTFFdnNmA+ti",
+	"cz/R7kkkpOqxGWYnMKy6E5I0GapLKfh8hLzRNRUdfXTN9p9Gxcz0GyuqgvCqmIHEKALmeLc1jBJosjDO",
+	"PDSpbn65ubn69N/Tj1e/XX2Zfv708ery/80wJdTBmHdvRzVALQQpKF+RjLLcuN21hqLUCh1KvoEopPMr",
+	"EVZGcWRLqlDWrkHL1dmHTIMMFmYJniqXgm0lJaHcaAXXlGl0TpE5Njah3SzgUzJEe5zgCi09LjUZ4nvw",
+	"6W8ZPRKl9pOOx8aqFjkko4kWskm5H6H1Ftjam+x/q1+N2IgUXqnt+amtVo6qXpRXknsgyfWqX5DtOmLb",
+	"8J2o9E6yM/ccl3D80pfQpsa5YesEypP26788n3lveXxsndE83wdfH/L8eSFmjr/AfaZHsq8gGxHI3KEb",
+	"jgGCsZnClIWdGX/iRHoZs4PQ26hUO5I912/m1LzVVomE3dR238iUiyOAhPT0XNO4LI0ZkoNSxBvzzAVS",
+	"NkDWRgH3wFgTCzwqxLoFkeP0x+CktpW6xiRPFsZtyedgkeQyzRBkveLg0QYQ42aPIdBVl3oQQg+sq/hg",
+	"vHHozaRYuiStF78FOVEXgdsrmhxVfzkzKQqkP7eERC+oJk6yzSJrRxYZnWihy0liCzl2cEVGvaKPI1FF",
+	"oKzkiWkiXJ8c3Cfqshb8uFtHrQiVcEK5eXUxOBdNNLc9BuHVxHzulD0rDxvHUyAFULt4jcjTNjfcuL/K",
+	"sifqCnRV7iXoWHp1zG3PZn3XkJTZjO+4AaebES/F+3xcHiRdGv1Ccwk0XXWtg1dBejZBUppKvSFGxpm3",
+	"uVvDQo8Ja45r2i4yn8zd7dlOeyvHb2fL5fLM1HidVTIHbmQ5PUSCvHrRp84ACZ5kFZQGY6QoTTXEVic6",
+	"a803ps0iVPyWiyXvmKrOzWHhqLBAR4smUzmnya2BryE+e8dFu2L2CMwR0sOMprUxdhqU4HzbnYDU6RFD",
+	"WwDW54XrXy/JX96/f0uYj2m0qzmxBdQWi16+UoyQ9SrDHCZ99rDg3YM5ru2N42aNkFQ78YwJzZUISrOZ",
+	"uFoP1njCZ9UoFbcZFBeaYN4Lck17fq5XHojMo3zecX5Aj3NepXoEUn1+/hOCNKGPI9LNGQw7JPqLly9/",
+	"dIEePKP6iU2C4QOiQyENuwiNC6NzTrUXQb00npyzS8G1FHm3L6GziEbGKBVvzvcmeLw2MZ020LMHdGMa",
+	"ZJ6LpWNNbxZeSWQkJPL+L+9+Ch3ejusZ+064De7w8iSZVpBnPpfcl1LcsRTkemLyYmc0ud1Ws4O88tk9",
+	"clk/sKOuDnfK88r0qG6NcFpATODN/A2ZCzHPIVxyV99/UNVPHC4fQuv/MV7kTinc+lw/yUKbGEkzevQI",
+	"m6kUkv2D6vrEoxS4PRwk1Gp9hvXzVDsdkkjaDJMhTPVqxF5HtyW0M99bEjQg6wTPdlQu0jW6VPBWtoQm",
+	"rcyNMHZSpyw15VbdubUn4nK75W5CQTWYrJ5mqnkIQ0d7h2NeSDTGdPDtKNFXx4pt5tpQ1pUSCaO5n3zV",
+	"lT10KDd7OY87B5QXutX21lw3ePfI1Fafvn+0C9ztlISUSUi0kYymV92pA56WgvVN2I9u+7GP9XoqtHZa",
+	"KRSBhdOCWH+wLw4W4XWqxSTB7yNt3+7Vh4zabykd67SS4Ieanj6BYkeaWlNHYidujPkQWOHS+PubDgtJ",
+	"OCzbf98xkVMNipQiZ8mJHlsy1sTq8Bqo786c7sHuJedQ7x0ztjPR4juUkVhfnWT4BZ79eM5+redIPBf+",
+	"FNCpZSRKUKD/dTISiTfebjZiAzC8Yz984cndRzyi4eTR1VeycffjiJ3E8LGfZWKRc5KHmNiut5WzZh/V",
+	"GY4RABdf2I5891GGo2G+8xmJJ8+m635w4hnKoB8RuRsnVryU0tJaGe0CqrvrWEjtftPjiY9j3PjkRlCz",
+	"23tws4AFObK5e+x5JqdFrv15nol01Y4GMVufqz3kdrKnRh+T3PpfxAghBjvxenbM4/W4Pc/8JZ6Vi3ix",
+	"eW4LYNIbrYqJI0Nl77qQQK2Td+J/CW1IHG7qe44oEaGvdQSDEJ34QNyv0TT7oyyn8/lr0uw4UNkP6ATd",
+	"CfXVPc+GrfG4+3DYBhXjPxx2t4/0RVSJjyxOUc/pSz/9dXuBsfmr/lTAkBL4e33PEWVg42s0oRVz00aS",
+	"nLJCxW28McspflX709XPl02iN3wr68NCTkpWYlduiaVVueBzkI5LXxhCP5XAr34ml4JzSLRb1Tqtr7I1",
+	"iNCZGjIDMyGKaNHCV03u3Qds1rvte4MzhWBLa1M/dJJxV1f4H08fUhi7v7nz9cji0/0ez9C2wp/f1y3G",
+	"0bYYI1N2L/q8yXb7066BssmV9oDJwS1QkD4m9+Z/exijfS4x/3lCPomD75a2E+OwbPempVO3bEfOSE1V",
+	"HA5kyVEQRk9T2GsDDvMLVYrNObxYKx0HOsxZBeV0Dm8IDhQUFhnZ8kZXdWS/YYWlADapaB1HZRVyylf6",
+	"lbgej7gwod8EVPEPzNvnDVjtqpqqbsLFmShf6e1fyuDy+eslGl62OMlxlxaPyF22G/Ku5qJK5tEFfklq",
+	"cvfDxOTPmm92/nMAOcStDECVAAA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
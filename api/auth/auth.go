@@ -4,24 +4,57 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"log"
 	"net/http"
 	"oapi-to-rest/pkg/db"
+	"oapi-to-rest/pkg/env"
 	"oapi-to-rest/pkg/errlib"
+	"oapi-to-rest/pkg/helper"
 	"oapi-to-rest/pkg/jwt"
+	"oapi-to-rest/pkg/mailer"
+	"oapi-to-rest/pkg/middleware"
+	"oapi-to-rest/pkg/oauth"
+	"oapi-to-rest/pkg/password"
+	"oapi-to-rest/pkg/revocation"
 	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
-	"golang.org/x/crypto/bcrypt"
+	"github.com/google/uuid"
 )
 
 type AuthImpl struct {
-	Db  *db.SQLite
-	Jwt *jwt.TokenManager
+	Db     *db.SQLite
+	Jwt    *jwt.TokenManager
+	Mailer mailer.Sender
+
+	// used to build links sent by email
+	AppBaseURL string
+
+	Cfg env.AuthConfig
+
+	// social login providers by name
+	OAuthProviders map[string]*oauth.Provider
+
+	PasswordPolicy *password.Policy
+	PasswordHasher *password.Hasher
+
+	// access tokens of invalidated sessions are put here
+	Revocations revocation.Store
 }
 
 var _ StrictServerInterface = (*AuthImpl)(nil)
 
+// context of the request behind a handler's *gin.Context. gin reuses its contexts once the
+// handler returns, while database/sql and net/http may still watch the context of a finished
+// query, transaction or provider call
+func requestContext(ctx context.Context) context.Context {
+	if ginCtx, ok := ctx.(*gin.Context); ok && ginCtx.Request != nil {
+		return ginCtx.Request.Context()
+	}
+	return ctx
+}
+
 func (a *AuthImpl) PostRegister(ctx context.Context, request PostRegisterRequestObject) (PostRegisterResponseObject, error) {
 
 	// validate email
@@ -37,20 +70,33 @@ func (a *AuthImpl) PostRegister(ctx context.Context, request PostRegisterRequest
 		return PostRegister400JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeEmailAlreadyUsed)
 	}
 
-	hashPassword, err := bcrypt.GenerateFromPassword([]byte(request.Body.Password), bcrypt.DefaultCost)
+	if err := a.checkPasswordPolicy("password", request.Body.Password, request.Body.Email); err != nil {
+		return PostRegister400JSONResponse{}, err
+	}
+
+	hashPassword, err := a.PasswordHasher.Hash(request.Body.Password)
 	if err != nil {
 		return PostRegister500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeInternalServer)
 	}
 
+	// new user starts unverified when email verification is required
+	emailVerified := 1
+	var verificationSentAt *time.Time
+	if a.Cfg.RequireEmailVerification {
+		emailVerified = 0
+		now := time.Now()
+		verificationSentAt = &now
+	}
+
 	tx, err := a.Db.DB.BeginTx(ctx, nil)
 	if err != nil {
 		return PostRegister500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBTransaction)
 	}
 
 	result, err := tx.ExecContext(ctx, `
-		INSERT INTO users (email, first_name, last_name)
-		VALUES (?, ?, ?)`,
-		request.Body.Email, request.Body.FirstName, request.Body.LastName,
+		INSERT INTO users (email, first_name, last_name, email_verified, email_verification_sent_at)
+		VALUES (?, ?, ?, ?, ?)`,
+		request.Body.Email, request.Body.FirstName, request.Body.LastName, emailVerified, verificationSentAt,
 	)
 	if err != nil {
 		tx.Rollback()
@@ -67,7 +113,7 @@ func (a *AuthImpl) PostRegister(ctx context.Context, request PostRegisterRequest
 	if _, err = tx.ExecContext(ctx, `
 		INSERT INTO auth_credentials (user_id, provider, password_hash)
 		VALUES (?, 'local', ?)`,
-		userID, string(hashPassword),
+		userID, hashPassword,
 	); err != nil {
 		tx.Rollback()
 		return PostRegister500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBTransaction)
@@ -78,11 +124,20 @@ func (a *AuthImpl) PostRegister(ctx context.Context, request PostRegisterRequest
 		return PostRegister500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBTransaction)
 	}
 
+	message := "success register new user"
+	if a.Cfg.RequireEmailVerification {
+		// user can request a new link through resend endpoint when delivery fails
+		if err := a.sendVerificationEmail(ctx, userID, request.Body.Email); err != nil {
+			log.Printf("failed to send verification email to user %d: %v", userID, err)
+		}
+		message = "success register new user, please verify your email address"
+	}
+
 	resp := RegisterResponse{
 		Data: struct {
 			Email *string "json:\"email,omitempty\""
 		}{Email: &request.Body.Email},
-		Message:    "success register new user",
+		Message:    message,
 		StatusCode: http.StatusCreated,
 	}
 
@@ -91,120 +146,169 @@ func (a *AuthImpl) PostRegister(ctx context.Context, request PostRegisterRequest
 
 func (a *AuthImpl) PostLogin(ctx context.Context, request PostLoginRequestObject) (PostLoginResponseObject, error) {
 
+	ginCtx, _ := ctx.(*gin.Context)
+	ip := ginCtx.ClientIP()
+
+	// reject while account or client ip is locked, before checking password
+	lockedFor, err := a.loginLockedFor(ctx, accountThrottleKey(request.Body.Email), ipThrottleKey(ip))
+	if err != nil {
+		return PostLogin500JSONResponse{}, err
+	}
+	if lockedFor > 0 {
+		return PostLogin429JSONResponse{}, errlib.NewAppError(errlib.ErrCodeAccountLocked).WithRetryAfter(lockedFor)
+	}
+
 	// check email exist
 	row := a.Db.DB.QueryRowContext(ctx, `
-		SELECT u.id, u.email, ac.password_hash FROM users u
-		LEFT join auth_credentials ac 
+		SELECT u.id, u.email, u.email_verified, ac.password_hash FROM users u
+		LEFT JOIN auth_credentials ac ON ac.user_id = u.id AND ac.provider = 'local'
 		WHERE u.email = $1 order by created_at desc limit 1;
 	`, request.Body.Email)
 
 	var userID int
 	var email string
-	var hashedPassword string
-	if err := row.Scan(&userID, &email, &hashedPassword); err != nil {
+	var emailVerified int
+	var hashedPassword sql.NullString
+	if err := row.Scan(&userID, &email, &emailVerified, &hashedPassword); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			// email not found
+			// email not found, counted like a wrong password
+			if err := a.recordLoginFailure(ctx, request.Body.Email, ip); err != nil {
+				return PostLogin500JSONResponse{}, err
+			}
 			return PostLogin401JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeInvalidEmailOrPassword)
 		}
 		return PostLogin500JSONResponse{}, err
 	}
 
-	// compare hash with bcrypt
-	if err := bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(request.Body.Password)); err != nil {
-
-		if err == bcrypt.ErrMismatchedHashAndPassword {
-			return PostLogin401JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeInvalidEmailOrPassword)
+	// user without local password (social login) never matches
+	var matched, rehash bool
+	if hashedPassword.Valid {
+		if matched, rehash, err = a.PasswordHasher.Verify(request.Body.Password, hashedPassword.String); err != nil {
+			return PostLogin500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeInternalServer)
+		}
+	}
+	if !matched {
+		if err := a.recordLoginFailure(ctx, request.Body.Email, ip); err != nil {
+			return PostLogin500JSONResponse{}, err
 		}
-		return PostLogin500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeInternalServer)
+		return PostLogin401JSONResponse{}, errlib.NewAppErrorWithLog(errors.New("password mismatch"), errlib.ErrCodeInvalidEmailOrPassword)
 	}
 
-	// create new user session
-	ginCtx, _ := ctx.(*gin.Context)
-	ip := ginCtx.ClientIP()
-	ua := ginCtx.Request.UserAgent()
+	// hash from older algorithm or cost is replaced while the plain password is known
+	if rehash {
+		a.upgradePasswordHash(ctx, strconv.Itoa(userID), hashedPassword.String, request.Body.Password)
+	}
 
-	// generate jwt
-	token, err := a.Jwt.GenerateJWT(jwt.CreateUserClaims(strconv.Itoa(userID), "", email, []string{}))
-	if err != nil {
-		return PostLogin500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeInternalServer)
+	// checked after password so unverified accounts are not disclosed to guessers
+	if a.Cfg.RequireEmailVerification && emailVerified != 1 {
+		return PostLogin403JSONResponse{}, errlib.NewAppErrorWithLog(errors.New("email not verified"), errlib.ErrCodeEmailNotVerified)
 	}
 
-	// generate refresh token
-	refreshToken, err := a.Jwt.GenerateRefreshToken()
+	resp, err := a.completeLogin(ctx, strconv.Itoa(userID), email)
 	if err != nil {
-		return PostLogin500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeInternalServer)
+		return PostLogin500JSONResponse{}, err
 	}
-	refreshExpiresAt := time.Now().Add(7 * 24 * time.Hour) // 7 days
 
-	tx, err := a.Db.DB.BeginTx(ctx, nil)
-	if err != nil {
-		return PostLogin500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBTransaction)
+	// failures are only forgotten once the login is complete, a pending second factor keeps
+	// counting so codes cannot be guessed over fresh challenges
+	if resp.Data.MfaRequired == nil {
+		if err := a.clearLoginFailures(ctx, request.Body.Email); err != nil {
+			return PostLogin500JSONResponse{}, err
+		}
 	}
-	defer tx.Rollback()
 
-	// store session with refresh token
-	if _, err = tx.ExecContext(ctx, `
-	INSERT INTO user_sessions (user_id, access_token, refresh_token, user_agent, ip_address, expires_at)
-	VALUES ($1, $2, $3, $4, $5, $6);
-`, strconv.Itoa(userID), token, refreshToken, ua, ip, refreshExpiresAt); err != nil {
-		return PostLogin500JSONResponse{}, err
+	return PostLogin200JSONResponse(resp), nil
+}
+
+// issue session for a user authenticated by first factor, or a challenge when mfa is enabled
+func (a *AuthImpl) completeLogin(ctx context.Context, userID, email string) (LoginResponse, error) {
+
+	// second factor required, tokens are issued by PostLoginMfa
+	mfaEnabled, err := a.hasTOTPEnabled(requestContext(ctx), userID)
+	if err != nil {
+		return LoginResponse{}, err
 	}
+	if mfaEnabled {
+		mfaToken, err := a.createMFAChallenge(requestContext(ctx), userID)
+		if err != nil {
+			return LoginResponse{}, err
+		}
 
-	if err := tx.Commit(); err != nil {
-		return PostLogin500JSONResponse{}, err
+		mfaRequired := true
+		resp := newLoginResponse("first factor accepted, second factor required", email, issuedSession{})
+		resp.Data.MfaRequired = &mfaRequired
+		resp.Data.MfaToken = &mfaToken
+		return resp, nil
 	}
 
-	resp := LoginResponse{
-		Data: &struct {
-			Email        *string "json:\"email,omitempty\""
-			RefreshToken *string "json:\"refresh_token,omitempty\""
-			Token        *string "json:\"token,omitempty\""
-		}{
-			Email:        &request.Body.Email,
-			RefreshToken: &refreshToken,
-			Token:        &token,
-		},
-		Message:    "success login",
-		StatusCode: http.StatusOK,
+	session, err := a.createSession(ctx, userID, email)
+	if err != nil {
+		return LoginResponse{}, err
 	}
 
-	return PostLogin200JSONResponse(resp), nil
+	return newLoginResponse("success login", email, session), nil
 }
 
 func (a *AuthImpl) PostRefresh(ctx context.Context, request PostRefreshRequestObject) (PostRefreshResponseObject, error) {
 
-	refreshToken := request.Body.RefreshToken
+	var refreshToken string
+	if request.Body.RefreshToken != nil {
+		refreshToken = *request.Body.RefreshToken
+	}
+
+	// sessions only store hash of the refresh token
+	refreshTokenHash := a.Jwt.HashToken(refreshToken)
 
 	// check refresh token
 	row := a.Db.DB.QueryRowContext(ctx, `
-		SELECT us.user_id, us.user_agent, us.is_valid, us.expires_at, us.access_token, u.email, 
+		SELECT us.user_id, us.user_agent, us.is_valid, us.expires_at, us.family_id, u.email
 		FROM user_sessions us
 		LEFT JOIN users u ON us.user_id = u.id
 		WHERE refresh_token = $1;
-	`, refreshToken)
+	`, refreshTokenHash)
 
 	var (
 		userID    string
 		userAgent string
 		isValid   int
 		expiresAt time.Time
+		familyID  sql.NullString
 		userEmail string
-		token     string
 	)
 
-	if err := row.Scan(&userID, &userAgent, &isValid, &expiresAt, &token, &userEmail); err != nil {
+	if err := row.Scan(&userID, &userAgent, &isValid, &expiresAt, &familyID, &userEmail); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return PostRefresh401JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeInvalidRefreshToken)
 		}
 		return PostRefresh500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBQuery)
 	}
 
-	if isValid != 1 || time.Now().After(expiresAt) {
-		return PostRefresh401JSONResponse{}, errlib.NewAppErrorWithLog(errors.New("expired or invalid refresh token"), errlib.ErrCodeInvalidRefreshToken)
+	ginCtx, _ := ctx.(*gin.Context)
+	ip := ginCtx.ClientIP()
+
+	// already rotated or revoked refresh token is replayed
+	if isValid != 1 {
+		a.revokeTokenFamily(ctx, familyID.String, userID, ip)
+		return PostRefresh401JSONResponse{}, errlib.NewAppErrorWithLog(errors.New("refresh token reused"), errlib.ErrCodeInvalidRefreshToken)
+	}
+
+	if time.Now().After(expiresAt) {
+		return PostRefresh401JSONResponse{}, errlib.NewAppErrorWithLog(errors.New("expired refresh token"), errlib.ErrCodeInvalidRefreshToken)
 	}
 
-	// generate new jwt and rotate refresh token
-	newToken, err := a.Jwt.RefreshJWT(token)
+	// session created before token families starts its own family on rotation
+	if !familyID.Valid {
+		familyID = sql.NullString{String: uuid.NewString(), Valid: true}
+	}
+
+	// generate new jwt from current user data and rotate refresh token
+	newSessionID := uuid.NewString()
+	claims, err := a.buildUserClaims(ctx, userID, userEmail, newSessionID)
+	if err != nil {
+		return PostRefresh500JSONResponse{}, err
+	}
+
+	newToken, err := a.Jwt.GenerateJWT(claims)
 	if err != nil {
 		return PostRefresh500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeInternalServer)
 	}
@@ -220,23 +324,32 @@ func (a *AuthImpl) PostRefresh(ctx context.Context, request PostRefreshRequestOb
 	}
 	defer tx.Rollback()
 
-	// invalidate old session
-	if _, err = tx.ExecContext(ctx, `
+	// invalidate old session, only one concurrent request can rotate the same refresh token
+	result, err := tx.ExecContext(ctx, `
 		UPDATE user_sessions
 		SET is_valid = 0
-		WHERE refresh_token = $1;
-	`, refreshToken); err != nil {
+		WHERE refresh_token = $1 AND is_valid = 1;
+	`, refreshTokenHash)
+	if err != nil {
 		return PostRefresh500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBTransaction)
 	}
 
-	ginCtx, _ := ctx.(*gin.Context)
-	ip := ginCtx.ClientIP()
+	rotated, err := result.RowsAffected()
+	if err != nil {
+		return PostRefresh500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBTransaction)
+	}
+	if rotated == 0 {
+		tx.Rollback()
+		a.revokeTokenFamily(ctx, familyID.String, userID, ip)
+		return PostRefresh401JSONResponse{}, errlib.NewAppErrorWithLog(errors.New("refresh token reused"), errlib.ErrCodeInvalidRefreshToken)
+	}
 
-	// insert new session
+	// insert new session in the same token family
+	accessToken := accessTokenOf(claims)
 	if _, err = tx.ExecContext(ctx, `
-		INSERT INTO user_sessions (user_id, access_token, refresh_token, user_agent, ip_address, expires_at)
-		VALUES ($1, $2, $3, $4, $5, $6)
-	`, userID, newToken, newRefresh, userAgent, ip, time.Now().Add(7*24*time.Hour)); err != nil {
+		INSERT INTO user_sessions (id, family_id, user_id, access_token, access_token_jti, access_token_expires_at, refresh_token, user_agent, device_class, ip_address, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`, newSessionID, familyID, userID, a.Jwt.HashToken(newToken), accessToken.jti, accessToken.expiresAt, a.Jwt.HashToken(newRefresh), userAgent, helper.UserAgentClass(userAgent), ip, time.Now().Add(refreshTokenTTL)); err != nil {
 		return PostRefresh500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBTransaction)
 	}
 
@@ -253,3 +366,107 @@ func (a *AuthImpl) PostRefresh(ctx context.Context, request PostRefreshRequestOb
 
 	return PostRefresh200JSONResponse(resp), nil
 }
+
+// revoke every session in the token family of a reused refresh token,
+// both the attacker and the legitimate user have to login again
+func (a *AuthImpl) revokeTokenFamily(ctx context.Context, familyID, userID, ip string) {
+
+	// sessions created before token families have no family to revoke
+	if familyID == "" {
+		log.Printf("security: reuse of refresh token without family detected, user_id=%s ip=%s", userID, ip)
+		return
+	}
+
+	rows, err := a.Db.DB.QueryContext(ctx, `
+		UPDATE user_sessions
+		SET is_valid = 0
+		WHERE family_id = $1 AND is_valid = 1
+		RETURNING access_token_jti, access_token_expires_at;
+	`, familyID)
+	if err != nil {
+		log.Printf("security: failed to revoke token family %s, err: %v", familyID, err)
+		return
+	}
+
+	tokens, err := scanSessionAccessTokens(rows)
+	if err != nil {
+		log.Printf("security: failed to revoke token family %s, err: %v", familyID, err)
+		return
+	}
+	a.revokeAccessTokens(ctx, tokens)
+
+	log.Printf("security: refresh token reuse detected, family_id=%s user_id=%s ip=%s revoked_sessions=%d", familyID, userID, ip, len(tokens))
+}
+
+func (a *AuthImpl) PostLogout(ctx context.Context, request PostLogoutRequestObject) (PostLogoutResponseObject, error) {
+
+	principal, ok := middleware.PrincipalFromContext(ctx)
+	if !ok {
+		return PostLogout401JSONResponse{}, errlib.NewAppErrorWithLog(errors.New("missing principal"), errlib.ErrCodeUnauthorized)
+	}
+
+	userID := principal.UserID
+	sessionID := principal.SessionID
+
+	// revoke current session and its access token
+	rows, err := a.Db.DB.QueryContext(ctx, `
+		UPDATE user_sessions
+		SET is_valid = 0
+		WHERE id = $1 AND user_id = $2 AND is_valid = 1
+		RETURNING access_token_jti, access_token_expires_at;
+	`, sessionID, userID)
+	if err != nil {
+		return PostLogout500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBQuery)
+	}
+
+	tokens, err := scanSessionAccessTokens(rows)
+	if err != nil {
+		return PostLogout500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBQuery)
+	}
+	a.revokeAccessTokens(ctx, tokens)
+
+	resp := newLogoutResponse("success logout", len(tokens))
+
+	return PostLogout200JSONResponse(resp), nil
+}
+
+func (a *AuthImpl) PostLogoutAll(ctx context.Context, request PostLogoutAllRequestObject) (PostLogoutAllResponseObject, error) {
+
+	principal, ok := middleware.PrincipalFromContext(ctx)
+	if !ok {
+		return PostLogoutAll401JSONResponse{}, errlib.NewAppErrorWithLog(errors.New("missing principal"), errlib.ErrCodeUnauthorized)
+	}
+
+	userID := principal.UserID
+
+	// revoke every session of the user and their access tokens, including current one
+	rows, err := a.Db.DB.QueryContext(ctx, `
+		UPDATE user_sessions
+		SET is_valid = 0
+		WHERE user_id = $1 AND is_valid = 1
+		RETURNING access_token_jti, access_token_expires_at;
+	`, userID)
+	if err != nil {
+		return PostLogoutAll500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBQuery)
+	}
+
+	tokens, err := scanSessionAccessTokens(rows)
+	if err != nil {
+		return PostLogoutAll500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBQuery)
+	}
+	a.revokeAccessTokens(ctx, tokens)
+
+	resp := newLogoutResponse("success logout from all sessions", len(tokens))
+
+	return PostLogoutAll200JSONResponse(resp), nil
+}
+
+func newLogoutResponse(message string, revoked int) LogoutResponse {
+	return LogoutResponse{
+		Data: &struct {
+			RevokedSessions *int "json:\"revoked_sessions,omitempty\""
+		}{RevokedSessions: &revoked},
+		Message:    message,
+		StatusCode: http.StatusOK,
+	}
+}
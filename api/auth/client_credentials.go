@@ -0,0 +1,57 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"oapi-to-rest/pkg/errlib"
+	"slices"
+	"strings"
+)
+
+const grantTypeClientCredentials = "client_credentials"
+
+// RFC 6749 4.4, token of a registered client for service to service calls, no refresh token is issued
+func (a *AuthImpl) PostOauthToken(ctx context.Context, request PostOauthTokenRequestObject) (PostOauthTokenResponseObject, error) {
+
+	client, err := a.authenticateClient(ctx)
+	if err != nil {
+		return PostOauthToken401JSONResponse{}, err
+	}
+
+	if request.Body == nil || request.Body.GrantType != grantTypeClientCredentials {
+		return PostOauthToken400JSONResponse{}, errlib.NewAppError(errlib.ErrCodeUnsupportedGrantType)
+	}
+
+	// requested scopes must be allowed for the client, none requested grants all of them
+	scopes := client.scopes
+	if request.Body.Scope != nil && strings.TrimSpace(*request.Body.Scope) != "" {
+		scopes = strings.Fields(*request.Body.Scope)
+		for _, scope := range scopes {
+			if !slices.Contains(client.scopes, scope) {
+				return PostOauthToken400JSONResponse{}, errlib.NewAppErrorWithLog(fmt.Errorf("scope %q not allowed for oauth client %s", scope, client.id), errlib.ErrCodeInvalidScope)
+			}
+		}
+	}
+
+	ttl := a.Jwt.ExpiresInSecond
+	if client.tokenTTL > 0 {
+		ttl = client.tokenTTL
+	}
+
+	token, err := a.Jwt.GenerateJWTWithTTL(a.Jwt.CreateClientClaims(client.id, scopes), ttl)
+	if err != nil {
+		return PostOauthToken500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeInternalServer)
+	}
+
+	resp := PostOauthToken200JSONResponse{
+		Body: ClientCredentialsResponse{
+			AccessToken: token,
+			TokenType:   "Bearer",
+			ExpiresIn:   int64(ttl.Seconds()),
+			Scope:       optionalString(strings.Join(scopes, " ")),
+		},
+	}
+	resp.Headers.CacheControl = "no-store"
+
+	return resp, nil
+}
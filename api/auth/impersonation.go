@@ -0,0 +1,140 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+	"oapi-to-rest/pkg/errlib"
+	"oapi-to-rest/pkg/jwt"
+	"oapi-to-rest/pkg/middleware"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const (
+	// role allowed to impersonate users
+	adminRole = "admin"
+
+	auditEventImpersonationStart = "impersonation.start"
+)
+
+func (a *AuthImpl) PostImpersonate(ctx context.Context, request PostImpersonateRequestObject) (PostImpersonateResponseObject, error) {
+
+	ginCtx, _ := ctx.(*gin.Context)
+	principal, ok := middleware.PrincipalFromContext(ctx)
+	if !ok {
+		return PostImpersonate401JSONResponse{}, errlib.NewAppErrorWithLog(errors.New("missing principal"), errlib.ErrCodeUnauthorized)
+	}
+
+	adminID := principal.UserID
+	adminEmail := principal.Email
+
+	// no impersonation chains
+	if principal.ActorID != "" {
+		return PostImpersonate403JSONResponse{}, errlib.NewAppErrorWithLog(errors.New("nested impersonation"), errlib.ErrCodeImpersonationDenied)
+	}
+
+	// roles are read from db, token roles may be stale
+	adminRoles, err := a.userRoles(ctx, adminID)
+	if err != nil {
+		return PostImpersonate500JSONResponse{}, err
+	}
+	if !slices.Contains(adminRoles, adminRole) {
+		return PostImpersonate403JSONResponse{}, errlib.NewAppErrorWithLog(errors.New("impersonation by non admin user "+adminID), errlib.ErrCodeForbidden)
+	}
+
+	reason := strings.TrimSpace(request.Body.Reason)
+	if reason == "" {
+		return PostImpersonate400JSONResponse{}, errlib.NewAppError(errlib.ErrCodeInvalidInput).WithFieldErrors("reason", "must not be empty")
+	}
+
+	var targetEmail string
+	err = a.Db.DB.QueryRowContext(ctx, `
+		SELECT email FROM users WHERE id = $1;
+	`, request.Body.UserId).Scan(&targetEmail)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return PostImpersonate404JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeUserNotFound)
+		}
+		return PostImpersonate500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBQuery)
+	}
+
+	targetID := strconv.FormatInt(request.Body.UserId, 10)
+	if targetID == adminID {
+		return PostImpersonate403JSONResponse{}, errlib.NewAppErrorWithLog(errors.New("impersonation of self"), errlib.ErrCodeImpersonationDenied)
+	}
+
+	// admins cannot borrow each other's privileges
+	targetRoles, err := a.userRoles(ctx, targetID)
+	if err != nil {
+		return PostImpersonate500JSONResponse{}, err
+	}
+	if slices.Contains(targetRoles, adminRole) {
+		return PostImpersonate403JSONResponse{}, errlib.NewAppErrorWithLog(errors.New("impersonation of admin user "+targetID), errlib.ErrCodeImpersonationDenied)
+	}
+
+	sessionID := uuid.NewString()
+	targetClaims, err := a.buildUserClaims(ctx, targetID, targetEmail, sessionID)
+	if err != nil {
+		return PostImpersonate500JSONResponse{}, err
+	}
+	targetClaims[jwt.ActorClaim] = map[string]any{
+		"sub":   adminID,
+		"email": adminEmail,
+	}
+
+	token, err := a.Jwt.GenerateJWTWithTTL(targetClaims, a.Cfg.ImpersonationTTL)
+	if err != nil {
+		return PostImpersonate500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeInternalServer)
+	}
+
+	ip := ginCtx.ClientIP()
+	ua := ginCtx.Request.UserAgent()
+	expiresAt := time.Now().Add(a.Cfg.ImpersonationTTL)
+	accessToken := accessTokenOf(targetClaims)
+
+	tx, err := a.Db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return PostImpersonate500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBTransaction)
+	}
+	defer tx.Rollback()
+
+	// flagged session without refresh token, it ends when the token expires or on logout
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO user_sessions (id, family_id, user_id, access_token, access_token_jti, access_token_expires_at, user_agent, ip_address, expires_at, impersonator_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10);
+	`, sessionID, uuid.NewString(), targetID, a.Jwt.HashToken(token), accessToken.jti, accessToken.expiresAt, ua, ip, expiresAt, adminID); err != nil {
+		return PostImpersonate500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBTransaction)
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO audit_log (event, actor_user_id, target_user_id, session_id, reason, ip_address, user_agent)
+		VALUES ($1, $2, $3, $4, $5, $6, $7);
+	`, auditEventImpersonationStart, adminID, targetID, sessionID, reason, ip, ua); err != nil {
+		return PostImpersonate500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBTransaction)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return PostImpersonate500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBTransaction)
+	}
+
+	log.Printf("security: user %s started impersonating user %s, session %s", adminID, targetID, sessionID)
+
+	resp := ImpersonateResponse{
+		Message:    "success impersonate user",
+		StatusCode: http.StatusOK,
+	}
+	resp.Data.Token = token
+	resp.Data.ExpiresAt = expiresAt
+	resp.Data.UserId = request.Body.UserId
+	resp.Data.Email = targetEmail
+
+	return PostImpersonate200JSONResponse(resp), nil
+}
@@ -0,0 +1,297 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"oapi-to-rest/pkg/errlib"
+	"oapi-to-rest/pkg/jwt"
+	"strings"
+	"time"
+
+	gojwt "github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	// client scope allowing to introspect tokens, without it every token is reported inactive
+	ScopeTokensIntrospect = "tokens:introspect"
+
+	// client scope allowing to revoke tokens not issued to the client itself
+	ScopeTokensRevoke = "tokens:revoke"
+)
+
+// RFC 7662, inactive tokens only report active=false whatever the reason
+func (a *AuthImpl) PostOauthIntrospect(ctx context.Context, request PostOauthIntrospectRequestObject) (PostOauthIntrospectResponseObject, error) {
+
+	client, err := a.authenticateClient(ctx)
+	if err != nil {
+		return PostOauthIntrospect401JSONResponse{}, err
+	}
+
+	// sub, email and roles of tokens are only disclosed to clients granted the scope
+	inactive := PostOauthIntrospect200JSONResponse{Active: false}
+	if !client.canIntrospect() || request.Body == nil || request.Body.Token == "" {
+		return inactive, nil
+	}
+
+	lookups := []func(context.Context, string) (*IntrospectionResponse, error){a.introspectAccessToken, a.introspectRefreshToken}
+	if hint := request.Body.TokenTypeHint; hint != nil && *hint == TokenRequestTokenTypeHintRefreshToken {
+		lookups[0], lookups[1] = lookups[1], lookups[0]
+	}
+
+	for _, lookup := range lookups {
+		resp, err := lookup(ctx, request.Body.Token)
+		if err != nil {
+			return PostOauthIntrospect500JSONResponse{}, err
+		}
+		if resp != nil {
+			return PostOauthIntrospect200JSONResponse(*resp), nil
+		}
+	}
+
+	return inactive, nil
+}
+
+// RFC 7009, unknown or already invalid tokens are not an error
+func (a *AuthImpl) PostOauthRevoke(ctx context.Context, request PostOauthRevokeRequestObject) (PostOauthRevokeResponseObject, error) {
+
+	client, err := a.authenticateClient(ctx)
+	if err != nil {
+		return PostOauthRevoke401JSONResponse{}, err
+	}
+
+	if request.Body == nil || request.Body.Token == "" {
+		return PostOauthRevoke200Response{}, nil
+	}
+
+	lookups := []func(context.Context, *oauthClient, string) (bool, error){a.revokeAccessToken, a.revokeRefreshToken}
+	if hint := request.Body.TokenTypeHint; hint != nil && *hint == TokenRequestTokenTypeHintRefreshToken {
+		lookups[0], lookups[1] = lookups[1], lookups[0]
+	}
+
+	for _, lookup := range lookups {
+		revoked, err := lookup(ctx, client, request.Body.Token)
+		var appErr *errlib.AppError
+		if errors.As(err, &appErr) && appErr.Code == errlib.ErrCodeUnauthorizedClient {
+			return PostOauthRevoke400JSONResponse{}, err
+		}
+		if err != nil {
+			return PostOauthRevoke500JSONResponse{}, err
+		}
+		if revoked {
+			log.Printf("oauth client %s revoked a token", client.id)
+			break
+		}
+	}
+
+	return PostOauthRevoke200Response{}, nil
+}
+
+// claims of a valid access token that is neither revoked nor bound to an invalidated session, nil otherwise
+func (a *AuthImpl) introspectAccessToken(ctx context.Context, token string) (*IntrospectionResponse, error) {
+
+	claims, err := a.Jwt.ValidateJWT(token)
+	if err != nil {
+		return nil, nil
+	}
+
+	jti, _ := claims[jwt.TokenIDClaim].(string)
+	if a.Revocations != nil && jti != "" {
+		revoked, err := a.Revocations.IsRevoked(ctx, jti)
+		if err != nil {
+			return nil, errlib.NewAppErrorWithLog(err, errlib.ErrCodeInternalServer)
+		}
+		if revoked {
+			return nil, nil
+		}
+	}
+
+	if sessionID, _ := claims[jwt.SessionIDClaim].(string); sessionID != "" {
+		valid, err := a.isSessionValid(ctx, sessionID)
+		if err != nil || !valid {
+			return nil, err
+		}
+	}
+
+	resp := &IntrospectionResponse{
+		Active:    true,
+		TokenType: tokenTypeOf(IntrospectionResponseTokenTypeAccessToken),
+		Sub:       claimString(claims, "sub"),
+		Iss:       claimString(claims, "iss"),
+		Jti:       claimString(claims, jwt.TokenIDClaim),
+		Sid:       claimString(claims, jwt.SessionIDClaim),
+		ClientId:  claimString(claims, jwt.ClientIDClaim),
+		Username:  claimString(claims, "email"),
+		Scope:     claimString(claims, jwt.ScopeClaim),
+		Exp:       claimUnix(claims, "exp"),
+		Iat:       claimUnix(claims, "iat"),
+		Nbf:       claimUnix(claims, "nbf"),
+	}
+	if aud, err := claims.GetAudience(); err == nil && len(aud) > 0 {
+		resp.Aud = optionalString(strings.Join(aud, " "))
+	}
+	if values, ok := claims["roles"].([]interface{}); ok {
+		roles := make([]string, 0, len(values))
+		for _, v := range values {
+			if role, ok := v.(string); ok {
+				roles = append(roles, role)
+			}
+		}
+		resp.Roles = &roles
+	}
+
+	return resp, nil
+}
+
+// session of a refresh token that is still valid, nil otherwise
+func (a *AuthImpl) introspectRefreshToken(ctx context.Context, token string) (*IntrospectionResponse, error) {
+
+	var (
+		sessionID string
+		userID    string
+		email     string
+		createdAt sql.NullTime
+		expiresAt sql.NullTime
+	)
+	err := a.Db.DB.QueryRowContext(ctx, `
+		SELECT us.id, us.user_id, u.email, us.created_at, us.expires_at
+		FROM user_sessions us
+		JOIN users u ON u.id = us.user_id
+		WHERE us.refresh_token = $1 AND us.is_valid = 1;
+	`, a.Jwt.HashToken(token)).Scan(&sessionID, &userID, &email, &createdAt, &expiresAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBQuery)
+	}
+	if !expiresAt.Valid || time.Now().After(expiresAt.Time) {
+		return nil, nil
+	}
+
+	exp := expiresAt.Time.Unix()
+	resp := &IntrospectionResponse{
+		Active:    true,
+		TokenType: tokenTypeOf(IntrospectionResponseTokenTypeRefreshToken),
+		Sub:       &userID,
+		Sid:       &sessionID,
+		Username:  &email,
+		Exp:       &exp,
+	}
+	if createdAt.Valid {
+		iat := createdAt.Time.Unix()
+		resp.Iat = &iat
+	}
+	if iss := a.Jwt.Issuer(); iss != "" {
+		resp.Iss = &iss
+	}
+
+	return resp, nil
+}
+
+// put a valid access token on the revocation list until it expires
+func (a *AuthImpl) revokeAccessToken(ctx context.Context, client *oauthClient, token string) (bool, error) {
+
+	claims, err := a.Jwt.ValidateJWT(token)
+	if err != nil {
+		return false, nil
+	}
+
+	// clients revoke their own tokens, others need the revoke scope
+	clientID, _ := claims[jwt.ClientIDClaim].(string)
+	subject, _ := claims.GetSubject()
+	if clientID != client.id && subject != client.id && !client.canRevokeAny() {
+		return false, unauthorizedClientError(client)
+	}
+
+	jti, _ := claims[jwt.TokenIDClaim].(string)
+	exp, err := claims.GetExpirationTime()
+	if jti == "" || err != nil || exp == nil || a.Revocations == nil {
+		return false, nil
+	}
+
+	if err := a.Revocations.Revoke(ctx, jti, exp.Time); err != nil {
+		return false, errlib.NewAppErrorWithLog(err, errlib.ErrCodeInternalServer)
+	}
+	return true, nil
+}
+
+// invalidate the session of a refresh token together with its access token
+func (a *AuthImpl) revokeRefreshToken(ctx context.Context, client *oauthClient, token string) (bool, error) {
+
+	// refresh tokens are only issued to users, never to a client
+	if !client.canRevokeAny() {
+		var exists int
+		err := a.Db.DB.QueryRowContext(ctx, `
+			SELECT 1 FROM user_sessions
+			WHERE refresh_token = $1 AND is_valid = 1;
+		`, a.Jwt.HashToken(token)).Scan(&exists)
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		if err != nil {
+			return false, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBQuery)
+		}
+		return false, unauthorizedClientError(client)
+	}
+
+	rows, err := a.Db.DB.QueryContext(ctx, `
+		UPDATE user_sessions
+		SET is_valid = 0
+		WHERE refresh_token = $1 AND is_valid = 1
+		RETURNING access_token_jti, access_token_expires_at;
+	`, a.Jwt.HashToken(token))
+	if err != nil {
+		return false, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBQuery)
+	}
+
+	tokens, err := scanSessionAccessTokens(rows)
+	if err != nil {
+		return false, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBQuery)
+	}
+	a.revokeAccessTokens(ctx, tokens)
+
+	return len(tokens) > 0, nil
+}
+
+func (a *AuthImpl) isSessionValid(ctx context.Context, sessionID string) (bool, error) {
+	var isValid int
+	err := a.Db.DB.QueryRowContext(ctx, `
+		SELECT is_valid FROM user_sessions
+		WHERE id = $1;
+	`, sessionID).Scan(&isValid)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBQuery)
+	}
+	return isValid == 1, nil
+}
+
+func claimString(claims gojwt.MapClaims, name string) *string {
+	value, _ := claims[name].(string)
+	return optionalString(value)
+}
+
+// numeric claims are float64 once parsed from json
+func claimUnix(claims gojwt.MapClaims, name string) *int64 {
+	value, ok := claims[name].(float64)
+	if !ok {
+		return nil
+	}
+	unix := int64(value)
+	return &unix
+}
+
+func tokenTypeOf(t IntrospectionResponseTokenType) *IntrospectionResponseTokenType {
+	return &t
+}
+
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
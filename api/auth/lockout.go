@@ -0,0 +1,111 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"oapi-to-rest/pkg/errlib"
+	"strings"
+	"time"
+)
+
+func accountThrottleKey(email string) string {
+	return "account:" + strings.ToLower(strings.TrimSpace(email))
+}
+
+func ipThrottleKey(ip string) string {
+	return "ip:" + ip
+}
+
+// remaining lock time of the given throttle keys, zero when none is locked
+func (a *AuthImpl) loginLockedFor(ctx context.Context, keys ...string) (time.Duration, error) {
+
+	var remaining time.Duration
+	now := time.Now()
+
+	for _, key := range keys {
+		var lockedUntil sql.NullTime
+		err := a.Db.DB.QueryRowContext(ctx, `
+			SELECT locked_until FROM login_throttles
+			WHERE key = $1;
+		`, key).Scan(&lockedUntil)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				continue
+			}
+			return 0, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBQuery)
+		}
+
+		if lockedUntil.Valid && lockedUntil.Time.After(now) && lockedUntil.Time.Sub(now) > remaining {
+			remaining = lockedUntil.Time.Sub(now)
+		}
+	}
+
+	return remaining, nil
+}
+
+// count failed login for account and client ip
+func (a *AuthImpl) recordLoginFailure(ctx context.Context, email, ip string) error {
+
+	// account gets progressive delay, ip is only locked after its own threshold
+	if err := a.recordThrottleFailure(ctx, accountThrottleKey(email), a.Cfg.LoginMaxFailuresAccount, true); err != nil {
+		return err
+	}
+	return a.recordThrottleFailure(ctx, ipThrottleKey(ip), a.Cfg.LoginMaxFailuresIP, false)
+}
+
+func (a *AuthImpl) recordThrottleFailure(ctx context.Context, key string, maxFailures int, progressive bool) error {
+
+	// counted in a single upsert so concurrent failures are not lost, failures outside of
+	// window are forgotten. times are stored in utc so they compare as text
+	now := time.Now().UTC()
+	var failures int
+	err := a.Db.DB.QueryRowContext(ctx, `
+		INSERT INTO login_throttles (key, failures, last_failed_at)
+		VALUES ($1, 1, $2)
+		ON CONFLICT(key) DO UPDATE SET
+			failures = CASE WHEN login_throttles.last_failed_at >= $3 THEN login_throttles.failures + 1 ELSE 1 END,
+			locked_until = CASE WHEN login_throttles.last_failed_at >= $3 THEN login_throttles.locked_until ELSE NULL END,
+			last_failed_at = excluded.last_failed_at
+		RETURNING failures;
+	`, key, now, now.Add(-a.Cfg.LoginFailureWindow)).Scan(&failures)
+	if err != nil {
+		return errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBQuery)
+	}
+
+	var lockedUntil time.Time
+	switch {
+	case maxFailures > 0 && failures >= maxFailures:
+		lockedUntil = now.Add(a.Cfg.LoginLockoutDuration)
+		log.Printf("security: login locked for %s after %d failed attempts", key, failures)
+	case progressive && a.Cfg.LoginDelayBase > 0:
+		delay := a.Cfg.LoginDelayBase << (failures - 1)
+		if delay <= 0 || delay > a.Cfg.LoginLockoutDuration {
+			delay = a.Cfg.LoginLockoutDuration
+		}
+		lockedUntil = now.Add(delay)
+	default:
+		return nil
+	}
+
+	// a concurrent failure may already have set a later lock
+	if _, err := a.Db.DB.ExecContext(ctx, `
+		UPDATE login_throttles SET locked_until = $1
+		WHERE key = $2 AND (locked_until IS NULL OR locked_until < $1);
+	`, lockedUntil, key); err != nil {
+		return errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBQuery)
+	}
+
+	return nil
+}
+
+// successful login resets the account counter, ip counter only decays with the window
+func (a *AuthImpl) clearLoginFailures(ctx context.Context, email string) error {
+	if _, err := a.Db.DB.ExecContext(ctx, `
+		DELETE FROM login_throttles WHERE key = $1;
+	`, accountThrottleKey(email)); err != nil {
+		return errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBQuery)
+	}
+	return nil
+}
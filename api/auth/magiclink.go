@@ -0,0 +1,188 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"oapi-to-rest/api/common"
+	"oapi-to-rest/pkg/errlib"
+	"oapi-to-rest/pkg/jwt"
+	"oapi-to-rest/pkg/mailer"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// minimum interval between two login links to the same user
+const magicLinkRequestInterval = time.Minute
+
+func (a *AuthImpl) PostMagicLinkRequest(ctx context.Context, request PostMagicLinkRequestRequestObject) (PostMagicLinkRequestResponseObject, error) {
+
+	// same response for unknown and throttled accounts, to avoid account enumeration
+	resp := common.BaseSuccessResponse{
+		Message:    "if the email is registered, a login link has been sent",
+		StatusCode: http.StatusOK,
+	}
+
+	var userID int64
+	err := a.Db.DB.QueryRowContext(ctx, `
+		SELECT u.id FROM users u
+		WHERE u.email = $1 order by created_at desc limit 1;
+	`, request.Body.Email).Scan(&userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return PostMagicLinkRequest200JSONResponse(resp), nil
+		}
+		return PostMagicLinkRequest500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBQuery)
+	}
+
+	// throttle per user
+	var lastCreatedAt sql.NullTime
+	err = a.Db.DB.QueryRowContext(ctx, `
+		SELECT created_at FROM magic_link_tokens
+		WHERE user_id = $1 order by created_at desc limit 1;
+	`, userID).Scan(&lastCreatedAt)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return PostMagicLinkRequest500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBQuery)
+	}
+	now := time.Now()
+	if lastCreatedAt.Valid && now.Sub(lastCreatedAt.Time) < magicLinkRequestInterval {
+		log.Printf("magic link request throttled for user %d", userID)
+		return PostMagicLinkRequest200JSONResponse(resp), nil
+	}
+
+	token, err := jwt.GenerateOpaqueToken()
+	if err != nil {
+		return PostMagicLinkRequest500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeInternalServer)
+	}
+
+	ginCtx, _ := ctx.(*gin.Context)
+	ua := ginCtx.Request.UserAgent()
+
+	tx, err := a.Db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return PostMagicLinkRequest500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBTransaction)
+	}
+	defer tx.Rollback()
+
+	// only the latest link of the user is usable
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE magic_link_tokens
+		SET used_at = CURRENT_TIMESTAMP
+		WHERE user_id = $1 AND used_at IS NULL;
+	`, userID); err != nil {
+		return PostMagicLinkRequest500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBTransaction)
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO magic_link_tokens (user_id, token_hash, user_agent_hash, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5);
+	`, userID, a.Jwt.HashToken(token), a.Jwt.HashToken(ua), now.Add(a.Cfg.MagicLinkTTL), now); err != nil {
+		return PostMagicLinkRequest500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBTransaction)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return PostMagicLinkRequest500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBTransaction)
+	}
+
+	link := fmt.Sprintf("%s/magic-link?token=%s", strings.TrimRight(a.AppBaseURL, "/"), url.QueryEscape(token))
+	msg := mailer.Message{
+		To:      request.Body.Email,
+		Subject: "Your login link",
+		Body: fmt.Sprintf("Use the link below to log in. It expires in %s and only works in the browser where it was requested.\n\n%s\n\n"+
+			"If you did not request a login link, you can ignore this email.\n",
+			a.Cfg.MagicLinkTTL, link),
+	}
+	if err := a.Mailer.Send(ctx, msg); err != nil {
+		// not reported to the client, the response must not reveal whether the email exists
+		log.Printf("failed to send magic link email to user %d: %v", userID, err)
+	}
+
+	return PostMagicLinkRequest200JSONResponse(resp), nil
+}
+
+func (a *AuthImpl) PostMagicLinkVerify(ctx context.Context, request PostMagicLinkVerifyRequestObject) (PostMagicLinkVerifyResponseObject, error) {
+
+	var (
+		linkID        int64
+		userID        int64
+		email         string
+		userAgentHash string
+		expiresAt     time.Time
+		usedAt        sql.NullTime
+	)
+	err := a.Db.DB.QueryRowContext(ctx, `
+		SELECT ml.id, ml.user_id, u.email, ml.user_agent_hash, ml.expires_at, ml.used_at
+		FROM magic_link_tokens ml
+		JOIN users u ON u.id = ml.user_id
+		WHERE ml.token_hash = $1;
+	`, a.Jwt.HashToken(request.Body.Token)).Scan(&linkID, &userID, &email, &userAgentHash, &expiresAt, &usedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return PostMagicLinkVerify400JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeInvalidMagicLink)
+		}
+		return PostMagicLinkVerify500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBQuery)
+	}
+
+	if usedAt.Valid {
+		return PostMagicLinkVerify400JSONResponse{}, errlib.NewAppErrorWithLog(errors.New("magic link already used"), errlib.ErrCodeInvalidMagicLink)
+	}
+
+	if time.Now().After(expiresAt) {
+		return PostMagicLinkVerify400JSONResponse{}, errlib.NewAppErrorWithLog(errors.New("expired magic link"), errlib.ErrCodeInvalidMagicLink)
+	}
+
+	// a link forwarded to or intercepted by another browser is useless there
+	ginCtx, _ := ctx.(*gin.Context)
+	if a.Jwt.HashToken(ginCtx.Request.UserAgent()) != userAgentHash {
+		return PostMagicLinkVerify400JSONResponse{}, errlib.NewAppErrorWithLog(errors.New("magic link opened with another user agent"), errlib.ErrCodeInvalidMagicLink)
+	}
+
+	tx, err := a.Db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return PostMagicLinkVerify500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBTransaction)
+	}
+	defer tx.Rollback()
+
+	// consume link, only one concurrent request can use the same link
+	result, err := tx.ExecContext(ctx, `
+		UPDATE magic_link_tokens
+		SET used_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND used_at IS NULL;
+	`, linkID)
+	if err != nil {
+		return PostMagicLinkVerify500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBTransaction)
+	}
+
+	consumed, err := result.RowsAffected()
+	if err != nil {
+		return PostMagicLinkVerify500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBTransaction)
+	}
+	if consumed == 0 {
+		return PostMagicLinkVerify400JSONResponse{}, errlib.NewAppErrorWithLog(errors.New("magic link already used"), errlib.ErrCodeInvalidMagicLink)
+	}
+
+	// the link was delivered to the mailbox, which proves ownership of the email
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE users SET email_verified = 1 WHERE id = $1;
+	`, userID); err != nil {
+		return PostMagicLinkVerify500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBTransaction)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return PostMagicLinkVerify500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBTransaction)
+	}
+
+	resp, err := a.completeLogin(ctx, strconv.FormatInt(userID, 10), email)
+	if err != nil {
+		return PostMagicLinkVerify500JSONResponse{}, err
+	}
+
+	return PostMagicLinkVerify200JSONResponse(resp), nil
+}
@@ -0,0 +1,379 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"oapi-to-rest/pkg/errlib"
+	"oapi-to-rest/pkg/helper"
+	"oapi-to-rest/pkg/jwt"
+	"oapi-to-rest/pkg/middleware"
+	"oapi-to-rest/pkg/totp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// lifetime of the challenge between password and second factor
+	mfaChallengeTTL = 5 * time.Minute
+
+	// wrong codes allowed per challenge before the user has to login again
+	mfaChallengeMaxAttempts = 5
+
+	recoveryCodeCount = 10
+)
+
+var errInvalidMFACode = errors.New("invalid mfa code")
+
+func (a *AuthImpl) PostMfaTotpSetup(ctx context.Context, request PostMfaTotpSetupRequestObject) (PostMfaTotpSetupResponseObject, error) {
+
+	principal, ok := middleware.PrincipalFromContext(ctx)
+	if !ok {
+		return PostMfaTotpSetup401JSONResponse{}, errlib.NewAppErrorWithLog(errors.New("missing principal"), errlib.ErrCodeUnauthorized)
+	}
+
+	userID := principal.UserID
+	email := principal.Email
+
+	enabled, err := a.hasTOTPEnabled(ctx, userID)
+	if err != nil {
+		return PostMfaTotpSetup500JSONResponse{}, err
+	}
+	if enabled {
+		return PostMfaTotpSetup400JSONResponse{}, errlib.NewAppErrorWithLog(errors.New("totp already enabled"), errlib.ErrCodeMFAAlreadyEnabled)
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return PostMfaTotpSetup500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeInternalServer)
+	}
+
+	key, err := helper.LoadAESKey(a.Cfg.MFAEncryptionKey)
+	if err != nil {
+		return PostMfaTotpSetup500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeInternalServer)
+	}
+	encrypted, err := helper.EncryptAESGCM(key, secret)
+	if err != nil {
+		return PostMfaTotpSetup500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeInternalServer)
+	}
+
+	// a new setup replaces any pending, unconfirmed enrollment
+	if _, err := a.Db.DB.ExecContext(ctx, `
+		INSERT INTO user_mfa_totp (user_id, secret_encrypted)
+		VALUES ($1, $2)
+		ON CONFLICT(user_id) DO UPDATE SET secret_encrypted = excluded.secret_encrypted, created_at = CURRENT_TIMESTAMP
+		WHERE user_mfa_totp.confirmed_at IS NULL;
+	`, userID, encrypted); err != nil {
+		return PostMfaTotpSetup500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBQuery)
+	}
+
+	resp := TotpSetupResponse{
+		Message:    "scan the otpauth uri with an authenticator app, then confirm with a code",
+		StatusCode: http.StatusOK,
+	}
+	resp.Data.Secret = secret
+	resp.Data.OtpauthUri = totp.URI(a.Cfg.MFAIssuer, email, secret)
+
+	return PostMfaTotpSetup200JSONResponse(resp), nil
+}
+
+func (a *AuthImpl) PostMfaTotpConfirm(ctx context.Context, request PostMfaTotpConfirmRequestObject) (PostMfaTotpConfirmResponseObject, error) {
+
+	principal, ok := middleware.PrincipalFromContext(ctx)
+	if !ok {
+		return PostMfaTotpConfirm401JSONResponse{}, errlib.NewAppErrorWithLog(errors.New("missing principal"), errlib.ErrCodeUnauthorized)
+	}
+
+	userID := principal.UserID
+
+	var (
+		encrypted   string
+		confirmedAt sql.NullTime
+	)
+	err := a.Db.DB.QueryRowContext(ctx, `
+		SELECT secret_encrypted, confirmed_at FROM user_mfa_totp
+		WHERE user_id = $1;
+	`, userID).Scan(&encrypted, &confirmedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return PostMfaTotpConfirm400JSONResponse{}, errlib.NewAppErrorWithLog(errors.New("no pending totp enrollment"), errlib.ErrCodeInvalidMFACode)
+		}
+		return PostMfaTotpConfirm500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBQuery)
+	}
+	if confirmedAt.Valid {
+		return PostMfaTotpConfirm400JSONResponse{}, errlib.NewAppErrorWithLog(errors.New("totp already enabled"), errlib.ErrCodeMFAAlreadyEnabled)
+	}
+
+	secret, err := a.decryptTOTPSecret(encrypted)
+	if err != nil {
+		return PostMfaTotpConfirm500JSONResponse{}, err
+	}
+
+	step, valid := totp.Validate(secret, request.Body.Code, time.Now())
+	if !valid {
+		return PostMfaTotpConfirm400JSONResponse{}, errlib.NewAppErrorWithLog(errInvalidMFACode, errlib.ErrCodeInvalidMFACode)
+	}
+
+	codes, err := generateRecoveryCodes()
+	if err != nil {
+		return PostMfaTotpConfirm500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeInternalServer)
+	}
+
+	tx, err := a.Db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return PostMfaTotpConfirm500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBTransaction)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE user_mfa_totp
+		SET confirmed_at = CURRENT_TIMESTAMP, last_used_step = $1
+		WHERE user_id = $2;
+	`, step, userID); err != nil {
+		return PostMfaTotpConfirm500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBTransaction)
+	}
+
+	// recovery codes are replaced as a whole
+	if _, err := tx.ExecContext(ctx, `
+		DELETE FROM mfa_recovery_codes WHERE user_id = $1;
+	`, userID); err != nil {
+		return PostMfaTotpConfirm500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBTransaction)
+	}
+	for _, code := range codes {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO mfa_recovery_codes (user_id, code_hash)
+			VALUES ($1, $2);
+		`, userID, a.Jwt.HashToken(normalizeRecoveryCode(code))); err != nil {
+			return PostMfaTotpConfirm500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBTransaction)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return PostMfaTotpConfirm500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBTransaction)
+	}
+
+	resp := RecoveryCodesResponse{
+		Message:    "totp enabled, store the recovery codes in a safe place",
+		StatusCode: http.StatusOK,
+	}
+	resp.Data.RecoveryCodes = codes
+
+	return PostMfaTotpConfirm200JSONResponse(resp), nil
+}
+
+func (a *AuthImpl) PostLoginMfa(ctx context.Context, request PostLoginMfaRequestObject) (PostLoginMfaResponseObject, error) {
+
+	var (
+		challengeID int64
+		userID      string
+		expiresAt   time.Time
+		usedAt      sql.NullTime
+		attempts    int
+		email       string
+	)
+	err := a.Db.DB.QueryRowContext(ctx, `
+		SELECT mc.id, mc.user_id, mc.expires_at, mc.used_at, u.email
+		FROM mfa_challenges mc
+		JOIN users u ON u.id = mc.user_id
+		WHERE mc.token_hash = $1;
+	`, a.Jwt.HashToken(request.Body.MfaToken)).Scan(&challengeID, &userID, &expiresAt, &usedAt, &email)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return PostLoginMfa401JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeInvalidMFAToken)
+		}
+		return PostLoginMfa500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBQuery)
+	}
+
+	if usedAt.Valid || time.Now().After(expiresAt) {
+		return PostLoginMfa401JSONResponse{}, errlib.NewAppErrorWithLog(errors.New("mfa challenge used or expired"), errlib.ErrCodeInvalidMFAToken)
+	}
+
+	// wrong codes share the login throttle, a new challenge from another password login does not reset it
+	ginCtx, _ := ctx.(*gin.Context)
+	ip := ginCtx.ClientIP()
+	lockedFor, err := a.loginLockedFor(ctx, accountThrottleKey(email), ipThrottleKey(ip))
+	if err != nil {
+		return PostLoginMfa500JSONResponse{}, err
+	}
+	if lockedFor > 0 {
+		return PostLoginMfa429JSONResponse{}, errlib.NewAppError(errlib.ErrCodeAccountLocked).WithRetryAfter(lockedFor)
+	}
+
+	// take an attempt before verifying so concurrent requests cannot exceed max attempts,
+	// challenge is burned once they are used up
+	err = a.Db.DB.QueryRowContext(ctx, `
+		UPDATE mfa_challenges
+		SET attempts = attempts + 1
+		WHERE id = $1 AND attempts < $2 AND used_at IS NULL
+		RETURNING attempts;
+	`, challengeID, mfaChallengeMaxAttempts).Scan(&attempts)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return PostLoginMfa401JSONResponse{}, errlib.NewAppErrorWithLog(errors.New("mfa challenge attempts exhausted"), errlib.ErrCodeInvalidMFAToken)
+		}
+		return PostLoginMfa500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBQuery)
+	}
+
+	if err := a.verifySecondFactor(ctx, userID, request.Body.Code); err != nil {
+		if !errors.Is(err, errInvalidMFACode) {
+			return PostLoginMfa500JSONResponse{}, err
+		}
+		if err := a.recordLoginFailure(ctx, email, ip); err != nil {
+			return PostLoginMfa500JSONResponse{}, err
+		}
+		return PostLoginMfa401JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeInvalidMFACode)
+	}
+
+	// consume challenge, only one concurrent request can use it
+	result, err := a.Db.DB.ExecContext(ctx, `
+		UPDATE mfa_challenges
+		SET used_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND used_at IS NULL;
+	`, challengeID)
+	if err != nil {
+		return PostLoginMfa500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBQuery)
+	}
+	if consumed, err := result.RowsAffected(); err != nil || consumed == 0 {
+		return PostLoginMfa401JSONResponse{}, errlib.NewAppErrorWithLog(errors.New("mfa challenge already used"), errlib.ErrCodeInvalidMFAToken)
+	}
+
+	if err := a.clearLoginFailures(ctx, email); err != nil {
+		return PostLoginMfa500JSONResponse{}, err
+	}
+
+	session, err := a.createSession(ctx, userID, email)
+	if err != nil {
+		return PostLoginMfa500JSONResponse{}, err
+	}
+
+	return PostLoginMfa200JSONResponse(newLoginResponse("success login", email, session)), nil
+}
+
+// verify totp code or unused recovery code of the user
+func (a *AuthImpl) verifySecondFactor(ctx context.Context, userID, code string) error {
+
+	var (
+		encrypted    string
+		lastUsedStep sql.NullInt64
+	)
+	err := a.Db.DB.QueryRowContext(ctx, `
+		SELECT secret_encrypted, last_used_step FROM user_mfa_totp
+		WHERE user_id = $1 AND confirmed_at IS NOT NULL;
+	`, userID).Scan(&encrypted, &lastUsedStep)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errInvalidMFACode
+		}
+		return errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBQuery)
+	}
+
+	secret, err := a.decryptTOTPSecret(encrypted)
+	if err != nil {
+		return err
+	}
+
+	if step, valid := totp.Validate(secret, code, time.Now()); valid {
+		// a code can only be used once, later steps move forward
+		result, err := a.Db.DB.ExecContext(ctx, `
+			UPDATE user_mfa_totp
+			SET last_used_step = $1
+			WHERE user_id = $2 AND (last_used_step IS NULL OR last_used_step < $1);
+		`, step, userID)
+		if err != nil {
+			return errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBQuery)
+		}
+		if updated, err := result.RowsAffected(); err != nil || updated == 0 {
+			return errInvalidMFACode
+		}
+		return nil
+	}
+
+	// fallback to single use recovery code
+	result, err := a.Db.DB.ExecContext(ctx, `
+		UPDATE mfa_recovery_codes
+		SET used_at = CURRENT_TIMESTAMP
+		WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL;
+	`, userID, a.Jwt.HashToken(normalizeRecoveryCode(code)))
+	if err != nil {
+		return errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBQuery)
+	}
+	if updated, err := result.RowsAffected(); err != nil || updated == 0 {
+		return errInvalidMFACode
+	}
+	return nil
+}
+
+func (a *AuthImpl) hasTOTPEnabled(ctx context.Context, userID string) (bool, error) {
+	var enabled int
+	err := a.Db.DB.QueryRowContext(ctx, `
+		SELECT COUNT(1) FROM user_mfa_totp
+		WHERE user_id = $1 AND confirmed_at IS NOT NULL;
+	`, userID).Scan(&enabled)
+	if err != nil {
+		return false, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBQuery)
+	}
+	return enabled > 0, nil
+}
+
+// opaque token exchanged with the second factor at PostLoginMfa
+func (a *AuthImpl) createMFAChallenge(ctx context.Context, userID string) (string, error) {
+	token, err := jwt.GenerateOpaqueToken()
+	if err != nil {
+		return "", errlib.NewAppErrorWithLog(err, errlib.ErrCodeInternalServer)
+	}
+
+	if _, err := a.Db.DB.ExecContext(ctx, `
+		INSERT INTO mfa_challenges (user_id, token_hash, expires_at)
+		VALUES ($1, $2, $3);
+	`, userID, a.Jwt.HashToken(token), time.Now().Add(mfaChallengeTTL)); err != nil {
+		return "", errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBQuery)
+	}
+	return token, nil
+}
+
+// user with a role listed in MFARequiredRoles must enable mfa
+func (a *AuthImpl) requiresMFA(roles []string) bool {
+	for _, role := range roles {
+		if slices.Contains(a.Cfg.MFARequiredRoles, role) {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *AuthImpl) decryptTOTPSecret(encrypted string) (string, error) {
+	key, err := helper.LoadAESKey(a.Cfg.MFAEncryptionKey)
+	if err != nil {
+		return "", errlib.NewAppErrorWithLog(err, errlib.ErrCodeInternalServer)
+	}
+	secret, err := helper.DecryptAESGCM(key, encrypted)
+	if err != nil {
+		return "", errlib.NewAppErrorWithLog(err, errlib.ErrCodeInternalServer)
+	}
+	return secret, nil
+}
+
+// recovery codes in the form xxxxx-xxxxx
+func generateRecoveryCodes() ([]string, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		raw := make([]byte, 5)
+		if _, err := rand.Read(raw); err != nil {
+			return nil, err
+		}
+		code := hex.EncodeToString(raw)
+		codes = append(codes, code[:5]+"-"+code[5:])
+	}
+	return codes, nil
+}
+
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	return strings.ReplaceAll(code, "-", "")
+}
@@ -0,0 +1,272 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"oapi-to-rest/pkg/errlib"
+	"oapi-to-rest/pkg/jwt"
+	"oapi-to-rest/pkg/oauth"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// lifetime of state between start and callback
+	oauthStateTTL = 10 * time.Minute
+
+	// cookie binding the state to the browser that started the login
+	oauthStateCookie = "oauth_state"
+)
+
+func (a *AuthImpl) GetOauthProviderStart(ctx context.Context, request GetOauthProviderStartRequestObject) (GetOauthProviderStartResponseObject, error) {
+
+	provider, ok := a.OAuthProviders[request.Provider]
+	if !ok {
+		return GetOauthProviderStart404JSONResponse{}, errlib.NewAppError(errlib.ErrCodeOAuthProviderNotFound)
+	}
+
+	state, err := jwt.GenerateOpaqueToken()
+	if err != nil {
+		return GetOauthProviderStart500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeInternalServer)
+	}
+
+	verifier, challenge, err := oauth.GeneratePKCE()
+	if err != nil {
+		return GetOauthProviderStart500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeInternalServer)
+	}
+
+	// expected in the id_token of OIDC providers
+	nonce, err := jwt.GenerateOpaqueToken()
+	if err != nil {
+		return GetOauthProviderStart500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeInternalServer)
+	}
+
+	if _, err := a.Db.DB.ExecContext(ctx, `
+		INSERT INTO oauth_states (state_hash, provider, code_verifier, nonce, expires_at)
+		VALUES ($1, $2, $3, $4, $5);
+	`, a.Jwt.HashToken(state), provider.Name(), verifier, nonce, time.Now().Add(oauthStateTTL)); err != nil {
+		return GetOauthProviderStart500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBQuery)
+	}
+
+	ginCtx, _ := ctx.(*gin.Context)
+	a.setOAuthStateCookie(ginCtx, state, int(oauthStateTTL.Seconds()))
+
+	resp := GetOauthProviderStart302Response{}
+	resp.Headers.Location = provider.AuthCodeURL(state, challenge, nonce)
+
+	return resp, nil
+}
+
+func (a *AuthImpl) GetOauthProviderCallback(ctx context.Context, request GetOauthProviderCallbackRequestObject) (GetOauthProviderCallbackResponseObject, error) {
+
+	provider, ok := a.OAuthProviders[request.Provider]
+	if !ok {
+		return GetOauthProviderCallback404JSONResponse{}, errlib.NewAppError(errlib.ErrCodeOAuthProviderNotFound)
+	}
+
+	// state must come from the same browser that started the login
+	ginCtx, _ := ctx.(*gin.Context)
+	cookieState, _ := ginCtx.Cookie(oauthStateCookie)
+	a.setOAuthStateCookie(ginCtx, "", -1)
+	if cookieState == "" || cookieState != request.Params.State {
+		return GetOauthProviderCallback400JSONResponse{}, errlib.NewAppErrorWithLog(errors.New("oauth state cookie mismatch"), errlib.ErrCodeInvalidOAuthState)
+	}
+	reqCtx := requestContext(ctx)
+
+	// state is single use
+	var (
+		stateProvider string
+		verifier      string
+		nonce         string
+		expiresAt     time.Time
+	)
+	err := a.Db.DB.QueryRowContext(reqCtx, `
+		DELETE FROM oauth_states
+		WHERE state_hash = $1
+		RETURNING provider, code_verifier, nonce, expires_at;
+	`, a.Jwt.HashToken(request.Params.State)).Scan(&stateProvider, &verifier, &nonce, &expiresAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return GetOauthProviderCallback400JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeInvalidOAuthState)
+		}
+		return GetOauthProviderCallback500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBQuery)
+	}
+	if stateProvider != provider.Name() || time.Now().After(expiresAt) {
+		return GetOauthProviderCallback400JSONResponse{}, errlib.NewAppErrorWithLog(errors.New("oauth state expired or issued for another provider"), errlib.ErrCodeInvalidOAuthState)
+	}
+
+	if request.Params.Error != nil || request.Params.Code == nil {
+		return GetOauthProviderCallback400JSONResponse{}, errlib.NewAppErrorWithLog(fmt.Errorf("oauth authorization denied: %v", request.Params.Error), errlib.ErrCodeOAuthDenied)
+	}
+
+	token, err := provider.Exchange(reqCtx, *request.Params.Code, verifier)
+	if err != nil {
+		return GetOauthProviderCallback502JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeOAuthProvider)
+	}
+
+	info, err := provider.Identity(reqCtx, token, nonce)
+	if err != nil {
+		return GetOauthProviderCallback502JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeOAuthProvider)
+	}
+
+	// linking and creating accounts by email is only safe with an email the provider has verified
+	if info.Email == "" || !info.EmailVerified {
+		return GetOauthProviderCallback400JSONResponse{}, errlib.NewAppErrorWithLog(errors.New("provider email missing or not verified"), errlib.ErrCodeOAuthEmailUnverified)
+	}
+
+	userID, email, err := a.resolveOAuthUser(reqCtx, provider.Name(), info)
+	var appErr *errlib.AppError
+	if errors.As(err, &appErr) && appErr.Code == errlib.ErrCodeOAuthAccountConflict {
+		return GetOauthProviderCallback409JSONResponse{}, err
+	}
+	if err != nil {
+		return GetOauthProviderCallback500JSONResponse{}, err
+	}
+
+	resp, err := a.completeLogin(ctx, userID, email)
+	if err != nil {
+		return GetOauthProviderCallback500JSONResponse{}, err
+	}
+
+	return GetOauthProviderCallback200JSONResponse(resp), nil
+}
+
+// find user linked to provider identity, else link by email, else create a password-less user
+func (a *AuthImpl) resolveOAuthUser(ctx context.Context, provider string, info *oauth.UserInfo) (string, string, error) {
+
+	tx, err := a.Db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return "", "", errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBTransaction)
+	}
+	defer tx.Rollback()
+
+	var (
+		userID        int64
+		email         string
+		emailVerified bool
+		revoked       []sessionAccessToken
+	)
+
+	// already linked identity
+	err = tx.QueryRowContext(ctx, `
+		SELECT u.id, u.email FROM auth_credentials ac
+		JOIN users u ON u.id = ac.user_id
+		WHERE ac.provider = $1 AND ac.provider_id = $2;
+	`, provider, info.Subject).Scan(&userID, &email)
+	if err == nil {
+		return strconv.FormatInt(userID, 10), email, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return "", "", errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBQuery)
+	}
+
+	// existing account with same email
+	err = tx.QueryRowContext(ctx, `
+		SELECT u.id, u.email, u.email_verified FROM users u
+		WHERE u.email = $1 order by created_at desc limit 1;
+	`, info.Email).Scan(&userID, &email, &emailVerified)
+	switch {
+	case err == nil:
+		// without verification anyone could have registered the email first, credentials they
+		// set up must not survive the owner signing in through the provider
+		if !a.Cfg.RequireEmailVerification || !emailVerified {
+			if revoked, err = dropUnprovenCredentials(ctx, tx, userID); err != nil {
+				return "", "", errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBTransaction)
+			}
+		}
+
+		// provider proved ownership of the email
+		if _, err := tx.ExecContext(ctx, `
+			UPDATE users SET email_verified = 1 WHERE id = $1;
+		`, userID); err != nil {
+			return "", "", errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBTransaction)
+		}
+	case errors.Is(err, sql.ErrNoRows):
+		firstName, lastName := info.GivenName, info.FamilyName
+		if firstName == "" && lastName == "" {
+			firstName, lastName, _ = strings.Cut(info.Name, " ")
+		}
+
+		result, err := tx.ExecContext(ctx, `
+			INSERT INTO users (email, first_name, last_name, email_verified)
+			VALUES ($1, $2, $3, 1);
+		`, info.Email, firstName, lastName)
+		if err != nil {
+			return "", "", errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBTransaction)
+		}
+		if userID, err = result.LastInsertId(); err != nil {
+			return "", "", errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBTransaction)
+		}
+		email = info.Email
+	default:
+		return "", "", errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBQuery)
+	}
+
+	// link identity, password_hash stays NULL. an existing link of the account to another
+	// identity of the provider is never replaced, that would hand the account to whoever owns it
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO auth_credentials (user_id, provider, provider_id)
+		VALUES ($1, $2, $3)
+		ON CONFLICT(user_id, provider) DO NOTHING;
+	`, userID, provider, info.Subject)
+	if err != nil {
+		return "", "", errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBTransaction)
+	}
+	if linked, err := result.RowsAffected(); err != nil || linked == 0 {
+		return "", "", errlib.NewAppErrorWithLog(fmt.Errorf("user %d is already linked to another %s identity", userID, provider), errlib.ErrCodeOAuthAccountConflict)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", "", errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBTransaction)
+	}
+	a.revokeAccessTokens(ctx, revoked)
+
+	return strconv.FormatInt(userID, 10), email, nil
+}
+
+// remove the local password of an account whose email was never proven, together with the
+// sessions, api keys and second factor it gave access to. returns access tokens to revoke
+func dropUnprovenCredentials(ctx context.Context, tx *sql.Tx, userID int64) ([]sessionAccessToken, error) {
+
+	result, err := tx.ExecContext(ctx, `
+		DELETE FROM auth_credentials WHERE user_id = $1 AND provider = 'local';
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	if deleted, err := result.RowsAffected(); err != nil || deleted == 0 {
+		return nil, err
+	}
+
+	for _, statement := range []string{
+		`UPDATE api_keys SET revoked_at = CURRENT_TIMESTAMP WHERE user_id = $1 AND revoked_at IS NULL;`,
+		`DELETE FROM user_mfa_totp WHERE user_id = $1;`,
+		`DELETE FROM mfa_recovery_codes WHERE user_id = $1;`,
+	} {
+		if _, err := tx.ExecContext(ctx, statement, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+		UPDATE user_sessions
+		SET is_valid = 0
+		WHERE user_id = $1 AND is_valid = 1
+		RETURNING access_token_jti, access_token_expires_at;
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	return scanSessionAccessTokens(rows)
+}
+
+func (a *AuthImpl) setOAuthStateCookie(c *gin.Context, value string, maxAge int) {
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(oauthStateCookie, value, maxAge, "/api/v1/auth/oauth", "", strings.HasPrefix(a.AppBaseURL, "https://"), true)
+}
@@ -0,0 +1,82 @@
+package auth
+
+import (
+	"context"
+	"crypto/subtle"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"oapi-to-rest/pkg/errlib"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// registered client calling the oauth endpoints
+type oauthClient struct {
+	id     string
+	scopes []string
+
+	// lifetime of client_credentials tokens, zero uses the configured one
+	tokenTTL time.Duration
+}
+
+// client from HTTP Basic credentials (RFC 6749 2.3.1), id and secret are form-urlencoded
+func (a *AuthImpl) authenticateClient(ctx context.Context) (*oauthClient, error) {
+
+	ginCtx, _ := ctx.(*gin.Context)
+	rawID, rawSecret, ok := ginCtx.Request.BasicAuth()
+	if !ok {
+		return nil, invalidClientError(errors.New("missing client credentials"))
+	}
+	clientID, errID := url.QueryUnescape(rawID)
+	secret, errSecret := url.QueryUnescape(rawSecret)
+	if errID != nil || errSecret != nil || clientID == "" || secret == "" {
+		return nil, invalidClientError(errors.New("malformed client credentials"))
+	}
+
+	var (
+		secretHash string
+		scopes     string
+		ttlSeconds sql.NullInt64
+	)
+	err := a.Db.DB.QueryRowContext(ctx, `
+		SELECT client_secret_hash, scopes, token_ttl_seconds FROM oauth_clients
+		WHERE client_id = $1 AND is_active = 1;
+	`, clientID).Scan(&secretHash, &scopes, &ttlSeconds)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, invalidClientError(errors.New("unknown oauth client " + clientID))
+		}
+		return nil, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBQuery)
+	}
+
+	if subtle.ConstantTimeCompare([]byte(a.Jwt.HashToken(secret)), []byte(secretHash)) != 1 {
+		return nil, invalidClientError(errors.New("invalid secret of oauth client " + clientID))
+	}
+
+	client := &oauthClient{id: clientID, scopes: strings.Fields(scopes)}
+	if ttlSeconds.Valid && ttlSeconds.Int64 > 0 {
+		client.tokenTTL = time.Duration(ttlSeconds.Int64) * time.Second
+	}
+	return client, nil
+}
+
+func invalidClientError(err error) *errlib.AppError {
+	return errlib.NewAppErrorWithLog(err, errlib.ErrCodeInvalidClient).WithHeader("WWW-Authenticate", `Basic realm="oauth"`)
+}
+
+func (c *oauthClient) canIntrospect() bool {
+	return slices.Contains(c.scopes, ScopeTokensIntrospect)
+}
+
+func (c *oauthClient) canRevokeAny() bool {
+	return slices.Contains(c.scopes, ScopeTokensRevoke)
+}
+
+func unauthorizedClientError(client *oauthClient) *errlib.AppError {
+	return errlib.NewAppErrorWithLog(fmt.Errorf("oauth client %s revoking a token not issued to it", client.id), errlib.ErrCodeUnauthorizedClient)
+}
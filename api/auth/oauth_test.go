@@ -0,0 +1,597 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"oapi-to-rest/pkg/db"
+	"oapi-to-rest/pkg/errlib"
+	"oapi-to-rest/pkg/jwt"
+	"oapi-to-rest/pkg/oauth"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	gojwt "github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	testProvider = "fake"
+	testClientID = "fake-client"
+	testKeyID    = "fake-key"
+)
+
+// authorization request remembered by the fake provider until its code is exchanged
+type fakeAuthorization struct {
+	challenge string
+	nonce     string
+}
+
+// OIDC provider serving authorize, token, userinfo and jwks endpoints
+type fakeOIDCProvider struct {
+	server *httptest.Server
+	key    *rsa.PrivateKey
+
+	// identity returned for every login
+	subject       string
+	email         string
+	emailVerified bool
+
+	// nonce put in id tokens instead of the one of the authorization request
+	nonceOverride string
+
+	mu    sync.Mutex
+	codes map[string]fakeAuthorization
+}
+
+func newFakeOIDCProvider(t *testing.T) *fakeOIDCProvider {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate provider key: %v", err)
+	}
+
+	p := &fakeOIDCProvider{
+		key:           key,
+		subject:       "fake-subject-1",
+		email:         "jane@example.com",
+		emailVerified: true,
+		codes:         map[string]fakeAuthorization{},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /authorize", p.authorize)
+	mux.HandleFunc("POST /token", p.token)
+	mux.HandleFunc("GET /userinfo", p.userinfo)
+	mux.HandleFunc("GET /jwks", p.jwks)
+	p.server = httptest.NewServer(mux)
+	t.Cleanup(p.server.Close)
+
+	return p
+}
+
+// issues a code for the PKCE challenge and nonce, and redirects back to the client
+func (p *fakeOIDCProvider) authorize(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if query.Get("client_id") != testClientID || query.Get("code_challenge_method") != "S256" {
+		http.Error(w, "invalid authorization request", http.StatusBadRequest)
+		return
+	}
+
+	code := rand.Text()
+	p.mu.Lock()
+	p.codes[code] = fakeAuthorization{challenge: query.Get("code_challenge"), nonce: query.Get("nonce")}
+	p.mu.Unlock()
+
+	redirect, _ := url.Parse(query.Get("redirect_uri"))
+	redirect.RawQuery = url.Values{"code": {code}, "state": {query.Get("state")}}.Encode()
+	http.Redirect(w, r, redirect.String(), http.StatusFound)
+}
+
+// exchanges a code once, the verifier must hash to the challenge of the authorization request
+func (p *fakeOIDCProvider) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	p.mu.Lock()
+	authorization, ok := p.codes[r.PostForm.Get("code")]
+	delete(p.codes, r.PostForm.Get("code"))
+	p.mu.Unlock()
+
+	sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	if !ok || base64.RawURLEncoding.EncodeToString(sum[:]) != authorization.challenge {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	nonce := authorization.nonce
+	if p.nonceOverride != "" {
+		nonce = p.nonceOverride
+	}
+
+	now := time.Now()
+	idToken := gojwt.NewWithClaims(gojwt.SigningMethodRS256, gojwt.MapClaims{
+		"iss":            p.server.URL,
+		"aud":            testClientID,
+		"sub":            p.subject,
+		"email":          p.email,
+		"email_verified": p.emailVerified,
+		"nonce":          nonce,
+		"iat":            now.Unix(),
+		"exp":            now.Add(time.Hour).Unix(),
+	})
+	idToken.Header["kid"] = testKeyID
+	signed, err := idToken.SignedString(p.key)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{
+		"access_token": "fake-access-token",
+		"token_type":   "Bearer",
+		"id_token":     signed,
+	})
+}
+
+func (p *fakeOIDCProvider) userinfo(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Bearer fake-access-token" {
+		http.Error(w, "invalid access token", http.StatusUnauthorized)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{
+		"sub":            p.subject,
+		"email":          p.email,
+		"email_verified": p.emailVerified,
+		"given_name":     "Jane",
+		"family_name":    "Doe",
+	})
+}
+
+func (p *fakeOIDCProvider) jwks(w http.ResponseWriter, r *http.Request) {
+	jwk, err := jwt.NewJWK(&p.key.PublicKey)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	jwk.Kid, jwk.Use, jwk.Alg = testKeyID, "sig", jwt.RS256
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string][]jwt.JWK{"keys": {jwk}})
+}
+
+// auth routes backed by a fresh database and the fake provider
+type oauthFixture struct {
+	auth     *AuthImpl
+	provider *fakeOIDCProvider
+	db       *db.SQLite
+	router   *gin.Engine
+}
+
+func newOAuthFixture(t *testing.T) *oauthFixture {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	sqlite, err := db.New(db.SQLiteConfig{Filepath: filepath.Join(t.TempDir(), "app.db")})
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	t.Cleanup(func() { sqlite.DB.Close() })
+
+	ddl, err := os.ReadFile("../../scripts/default_sqlite_ddl.sql")
+	if err != nil {
+		t.Fatalf("read ddl: %v", err)
+	}
+	if _, err := sqlite.DB.Exec(string(ddl)); err != nil {
+		t.Fatalf("create schema: %v", err)
+	}
+
+	tm, err := jwt.NewJwtInit(&jwt.JwtConfig{
+		Mode:            "HS256",
+		Secret:          "test-secret-test-secret-test-secret",
+		ExpiresInSecond: 15 * time.Minute,
+		TokenHashSecret: "test-token-hash-secret-of-32-bytes",
+	})
+	if err != nil {
+		t.Fatalf("init jwt: %v", err)
+	}
+
+	provider := newFakeOIDCProvider(t)
+	impl := &AuthImpl{
+		Db:         sqlite,
+		Jwt:        tm,
+		AppBaseURL: "http://localhost",
+		OAuthProviders: map[string]*oauth.Provider{
+			testProvider: oauth.NewProvider(oauth.ProviderConfig{
+				Name:         testProvider,
+				ClientID:     testClientID,
+				ClientSecret: "fake-secret",
+				AuthURL:      provider.server.URL + "/authorize",
+				TokenURL:     provider.server.URL + "/token",
+				UserInfoURL:  provider.server.URL + "/userinfo",
+				RedirectURL:  "http://localhost/api/v1/auth/oauth/" + testProvider + "/callback",
+				Scopes:       []string{"openid", "email", "profile"},
+				Issuer:       provider.server.URL,
+				JWKSURL:      provider.server.URL + "/jwks",
+			}),
+		},
+	}
+
+	router := gin.New()
+	router.Use(errlib.ErrorHandlerGinMiddleware(*errlib.NewErrorHandler(false)))
+	RegisterHandlers(router.Group("/api/v1/auth"), NewStrictHandler(impl, nil))
+
+	return &oauthFixture{auth: impl, provider: provider, db: sqlite, router: router}
+}
+
+// starts a login, returns the state cookie and the provider authorization url
+func (f *oauthFixture) start(t *testing.T) (*http.Cookie, string) {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	f.router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/auth/oauth/"+testProvider+"/start", nil))
+	if rec.Code != http.StatusFound {
+		t.Fatalf("start: status %d, body %s", rec.Code, rec.Body)
+	}
+
+	for _, cookie := range rec.Result().Cookies() {
+		if cookie.Name == oauthStateCookie {
+			return cookie, rec.Header().Get("Location")
+		}
+	}
+	t.Fatal("start: state cookie not set")
+	return nil, ""
+}
+
+// user consents at the provider, returns code and state of the redirect back
+func (f *oauthFixture) authorize(t *testing.T, authURL string) (string, string) {
+	t.Helper()
+
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+	resp, err := client.Get(authURL)
+	if err != nil {
+		t.Fatalf("authorize: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusFound {
+		t.Fatalf("authorize: status %d", resp.StatusCode)
+	}
+
+	location, err := url.Parse(resp.Header.Get("Location"))
+	if err != nil {
+		t.Fatalf("authorize: %v", err)
+	}
+	return location.Query().Get("code"), location.Query().Get("state")
+}
+
+func (f *oauthFixture) callback(cookie *http.Cookie, code, state string) *httptest.ResponseRecorder {
+	query := url.Values{"code": {code}, "state": {state}}
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/auth/oauth/"+testProvider+"/callback?"+query.Encode(), nil)
+	if cookie != nil {
+		req.AddCookie(cookie)
+	}
+
+	rec := httptest.NewRecorder()
+	f.router.ServeHTTP(rec, req)
+	return rec
+}
+
+// whole login from start to callback
+func (f *oauthFixture) login(t *testing.T) *httptest.ResponseRecorder {
+	t.Helper()
+
+	cookie, authURL := f.start(t)
+	code, state := f.authorize(t, authURL)
+	return f.callback(cookie, code, state)
+}
+
+func (f *oauthFixture) count(t *testing.T, query string, args ...any) int {
+	t.Helper()
+
+	var n int
+	if err := f.db.DB.QueryRow(query, args...).Scan(&n); err != nil {
+		t.Fatalf("count: %v", err)
+	}
+	return n
+}
+
+func decodeLogin(t *testing.T, rec *httptest.ResponseRecorder) LoginResponse {
+	t.Helper()
+
+	var resp LoginResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode login response: %v, body %s", err, rec.Body)
+	}
+	if resp.Data == nil || resp.Data.Token == nil || resp.Data.RefreshToken == nil {
+		t.Fatalf("login response without tokens: %s", rec.Body)
+	}
+	return resp
+}
+
+func expectStatus(t *testing.T, rec *httptest.ResponseRecorder, status int) {
+	t.Helper()
+	if rec.Code != status {
+		t.Fatalf("callback: status %d, want %d, body %s", rec.Code, status, rec.Body)
+	}
+}
+
+func TestOAuthLoginFlow(t *testing.T) {
+	f := newOAuthFixture(t)
+
+	cookie, authURL := f.start(t)
+	location, err := url.Parse(authURL)
+	if err != nil {
+		t.Fatalf("parse authorization url: %v", err)
+	}
+	query := location.Query()
+	if query.Get("state") != cookie.Value {
+		t.Errorf("state in authorization url %q, cookie %q", query.Get("state"), cookie.Value)
+	}
+	if query.Get("code_challenge") == "" || query.Get("nonce") == "" {
+		t.Errorf("authorization url without PKCE challenge or nonce: %s", authURL)
+	}
+
+	code, state := f.authorize(t, authURL)
+	rec := f.callback(cookie, code, state)
+	expectStatus(t, rec, http.StatusOK)
+
+	resp := decodeLogin(t, rec)
+	if *resp.Data.Email != f.provider.email {
+		t.Errorf("logged in as %q, want %q", *resp.Data.Email, f.provider.email)
+	}
+	if n := f.count(t, `SELECT COUNT(*) FROM user_sessions`); n != 1 {
+		t.Errorf("%d sessions, want 1", n)
+	}
+	if n := f.count(t, `SELECT COUNT(*) FROM oauth_states`); n != 0 {
+		t.Errorf("%d states left after callback, want 0", n)
+	}
+}
+
+func TestOAuthCallbackStateMismatch(t *testing.T) {
+	f := newOAuthFixture(t)
+
+	cookie, authURL := f.start(t)
+	code, _ := f.authorize(t, authURL)
+
+	// state of another login
+	_, otherAuthURL := f.start(t)
+	_, otherState := f.authorize(t, otherAuthURL)
+
+	expectStatus(t, f.callback(cookie, code, otherState), http.StatusBadRequest)
+	expectStatus(t, f.callback(nil, code, cookie.Value), http.StatusBadRequest)
+
+	if n := f.count(t, `SELECT COUNT(*) FROM users`); n != 0 {
+		t.Errorf("%d users created, want 0", n)
+	}
+}
+
+func TestOAuthCallbackStateReplay(t *testing.T) {
+	f := newOAuthFixture(t)
+
+	cookie, authURL := f.start(t)
+	code, state := f.authorize(t, authURL)
+	expectStatus(t, f.callback(cookie, code, state), http.StatusOK)
+
+	// state is consumed by the first callback
+	expectStatus(t, f.callback(cookie, code, state), http.StatusBadRequest)
+
+	if n := f.count(t, `SELECT COUNT(*) FROM user_sessions`); n != 1 {
+		t.Errorf("%d sessions, want 1", n)
+	}
+}
+
+func TestOAuthCallbackBadPKCEVerifier(t *testing.T) {
+	f := newOAuthFixture(t)
+
+	cookie, authURL := f.start(t)
+	code, state := f.authorize(t, authURL)
+
+	// verifier no longer matches the challenge sent to the provider
+	if _, err := f.db.DB.Exec(`UPDATE oauth_states SET code_verifier = 'not-the-verifier'`); err != nil {
+		t.Fatalf("update verifier: %v", err)
+	}
+
+	expectStatus(t, f.callback(cookie, code, state), http.StatusBadGateway)
+
+	if n := f.count(t, `SELECT COUNT(*) FROM users`); n != 0 {
+		t.Errorf("%d users created, want 0", n)
+	}
+}
+
+func TestOAuthCallbackNonceMismatch(t *testing.T) {
+	f := newOAuthFixture(t)
+	f.provider.nonceOverride = "nonce-of-another-login"
+
+	expectStatus(t, f.login(t), http.StatusBadGateway)
+
+	if n := f.count(t, `SELECT COUNT(*) FROM users`); n != 0 {
+		t.Errorf("%d users created, want 0", n)
+	}
+}
+
+func TestOAuthCallbackUnverifiedEmail(t *testing.T) {
+	f := newOAuthFixture(t)
+	f.provider.emailVerified = false
+
+	rec := f.login(t)
+	expectStatus(t, rec, http.StatusBadRequest)
+
+	var resp errlib.ErrorResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode error response: %v", err)
+	}
+	if want := errlib.NewAppError(errlib.ErrCodeOAuthEmailUnverified).Message; resp.Title != want {
+		t.Errorf("error %q, want %q", resp.Title, want)
+	}
+	if n := f.count(t, `SELECT COUNT(*) FROM users`); n != 0 {
+		t.Errorf("%d users created, want 0", n)
+	}
+}
+
+// account registered with a password, returns its id
+func (f *oauthFixture) insertLocalUser(t *testing.T, emailVerified int) int64 {
+	t.Helper()
+
+	result, err := f.db.DB.Exec(`
+		INSERT INTO users (email, first_name, email_verified) VALUES ($1, 'Jane', $2);
+	`, f.provider.email, emailVerified)
+	if err != nil {
+		t.Fatalf("insert user: %v", err)
+	}
+	userID, _ := result.LastInsertId()
+	if _, err := f.db.DB.Exec(`
+		INSERT INTO auth_credentials (user_id, provider, password_hash) VALUES ($1, 'local', 'hash');
+	`, userID); err != nil {
+		t.Fatalf("insert credential: %v", err)
+	}
+	return userID
+}
+
+func TestOAuthLinksExistingAccountByEmail(t *testing.T) {
+	f := newOAuthFixture(t)
+	f.auth.Cfg.RequireEmailVerification = true
+	userID := f.insertLocalUser(t, 1)
+
+	expectStatus(t, f.login(t), http.StatusOK)
+
+	if n := f.count(t, `SELECT COUNT(*) FROM users`); n != 1 {
+		t.Errorf("%d users, want the existing one only", n)
+	}
+	if n := f.count(t, `
+		SELECT COUNT(*) FROM auth_credentials WHERE user_id = $1 AND provider = $2 AND provider_id = $3;
+	`, userID, testProvider, f.provider.subject); n != 1 {
+		t.Errorf("provider identity not linked to existing user %d", userID)
+	}
+
+	// next login finds the linked identity
+	expectStatus(t, f.login(t), http.StatusOK)
+	if n := f.count(t, `SELECT COUNT(*) FROM auth_credentials WHERE user_id = $1`, userID); n != 2 {
+		t.Errorf("%d credentials, want local and %s", n, testProvider)
+	}
+	if n := f.count(t, `SELECT COUNT(*) FROM user_sessions WHERE user_id = $1 AND is_valid = 1`, userID); n != 2 {
+		t.Errorf("%d valid sessions, want 2", n)
+	}
+}
+
+func TestOAuthLinkDropsCredentialsOfUnprovenEmail(t *testing.T) {
+	for _, tc := range []struct {
+		name                     string
+		requireEmailVerification bool
+		emailVerified            int
+	}{
+		{name: "verification not required", requireEmailVerification: false, emailVerified: 1},
+		{name: "email not verified", requireEmailVerification: true, emailVerified: 0},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			f := newOAuthFixture(t)
+			f.auth.Cfg.RequireEmailVerification = tc.requireEmailVerification
+			userID := f.insertLocalUser(t, tc.emailVerified)
+
+			// whoever registered the email first is logged in
+			if _, err := f.db.DB.Exec(`
+				INSERT INTO user_sessions (user_id, refresh_token, expires_at) VALUES ($1, 'pre-registered', $2);
+			`, userID, time.Now().Add(time.Hour)); err != nil {
+				t.Fatalf("insert session: %v", err)
+			}
+			if _, err := f.db.DB.Exec(`
+				INSERT INTO api_keys (id, user_id, name, prefix, key_hash) VALUES ('key-1', $1, 'key', 'prefix', 'hash');
+			`, userID); err != nil {
+				t.Fatalf("insert api key: %v", err)
+			}
+
+			expectStatus(t, f.login(t), http.StatusOK)
+
+			if n := f.count(t, `SELECT COUNT(*) FROM auth_credentials WHERE user_id = $1 AND provider = 'local'`, userID); n != 0 {
+				t.Error("password set before the email was proven survived the link")
+			}
+			if n := f.count(t, `SELECT COUNT(*) FROM user_sessions WHERE refresh_token = 'pre-registered' AND is_valid = 1`); n != 0 {
+				t.Error("session opened with that password is still valid")
+			}
+			if n := f.count(t, `SELECT COUNT(*) FROM api_keys WHERE user_id = $1 AND revoked_at IS NULL`, userID); n != 0 {
+				t.Error("api key created with that password is not revoked")
+			}
+			if n := f.count(t, `SELECT COUNT(*) FROM users WHERE id = $1 AND email_verified = 1`, userID); n != 1 {
+				t.Error("email of linked user not marked verified")
+			}
+			if n := f.count(t, `SELECT COUNT(*) FROM user_sessions WHERE user_id = $1 AND is_valid = 1`, userID); n != 1 {
+				t.Errorf("%d valid sessions, want the provider login only", n)
+			}
+		})
+	}
+}
+
+func TestOAuthRejectsAccountLinkedToAnotherIdentity(t *testing.T) {
+	f := newOAuthFixture(t)
+
+	result, err := f.db.DB.Exec(`INSERT INTO users (email) VALUES ($1)`, f.provider.email)
+	if err != nil {
+		t.Fatalf("insert user: %v", err)
+	}
+	userID, _ := result.LastInsertId()
+	if _, err := f.db.DB.Exec(`
+		INSERT INTO auth_credentials (user_id, provider, provider_id) VALUES ($1, $2, 'fake-subject-other');
+	`, userID, testProvider); err != nil {
+		t.Fatalf("insert credential: %v", err)
+	}
+
+	expectStatus(t, f.login(t), http.StatusConflict)
+
+	if n := f.count(t, `
+		SELECT COUNT(*) FROM auth_credentials WHERE provider_id = 'fake-subject-other';
+	`); n != 1 {
+		t.Error("existing identity link was replaced")
+	}
+}
+
+func TestOAuthCreatesPasswordlessUser(t *testing.T) {
+	f := newOAuthFixture(t)
+
+	expectStatus(t, f.login(t), http.StatusOK)
+
+	var (
+		userID        int64
+		firstName     string
+		emailVerified int
+		passwordHash  sql.NullString
+	)
+	err := f.db.DB.QueryRow(`
+		SELECT u.id, u.first_name, u.email_verified, ac.password_hash FROM users u
+		JOIN auth_credentials ac ON ac.user_id = u.id
+		WHERE u.email = $1 AND ac.provider = $2 AND ac.provider_id = $3;
+	`, f.provider.email, testProvider, f.provider.subject).Scan(&userID, &firstName, &emailVerified, &passwordHash)
+	if err != nil {
+		t.Fatalf("created user: %v", err)
+	}
+
+	if passwordHash.Valid {
+		t.Errorf("password_hash %q, want NULL", passwordHash.String)
+	}
+	if emailVerified != 1 {
+		t.Error("email of created user not verified")
+	}
+	if firstName != "Jane" {
+		t.Errorf("first name %q, want Jane", firstName)
+	}
+	if n := f.count(t, `SELECT COUNT(*) FROM auth_credentials WHERE user_id = $1`, userID); n != 1 {
+		t.Errorf("%d credentials, want the %s identity only", n, testProvider)
+	}
+}
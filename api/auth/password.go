@@ -0,0 +1,316 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"oapi-to-rest/api/common"
+	"oapi-to-rest/pkg/errlib"
+	"oapi-to-rest/pkg/jwt"
+	"oapi-to-rest/pkg/mailer"
+	"oapi-to-rest/pkg/middleware"
+	"strings"
+	"time"
+)
+
+// lifetime of a password reset link
+const passwordResetTokenTTL = 30 * time.Minute
+
+func (a *AuthImpl) PostPasswordChange(ctx context.Context, request PostPasswordChangeRequestObject) (PostPasswordChangeResponseObject, error) {
+
+	principal, ok := middleware.PrincipalFromContext(ctx)
+	if !ok {
+		return PostPasswordChange401JSONResponse{}, errlib.NewAppErrorWithLog(errors.New("missing principal"), errlib.ErrCodeUnauthorized)
+	}
+
+	userID := principal.UserID
+	email := principal.Email
+	sessionID := principal.SessionID
+
+	// wrong current passwords share the login throttle of the account
+	lockedFor, err := a.loginLockedFor(ctx, accountThrottleKey(email))
+	if err != nil {
+		return PostPasswordChange500JSONResponse{}, err
+	}
+	if lockedFor > 0 {
+		return PostPasswordChange429JSONResponse{}, errlib.NewAppError(errlib.ErrCodeAccountLocked).WithRetryAfter(lockedFor)
+	}
+
+	var hashedPassword sql.NullString
+	err = a.Db.DB.QueryRowContext(ctx, `
+		SELECT password_hash FROM auth_credentials
+		WHERE user_id = $1 AND provider = 'local';
+	`, userID).Scan(&hashedPassword)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return PostPasswordChange500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBQuery)
+	}
+
+	// user without local password sets one through password reset
+	var matched bool
+	if hashedPassword.Valid {
+		if matched, _, err = a.PasswordHasher.Verify(request.Body.CurrentPassword, hashedPassword.String); err != nil {
+			return PostPasswordChange500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeInternalServer)
+		}
+	}
+	if !matched {
+		if err := a.recordThrottleFailure(ctx, accountThrottleKey(email), a.Cfg.LoginMaxFailuresAccount, true); err != nil {
+			return PostPasswordChange500JSONResponse{}, err
+		}
+		return PostPasswordChange400JSONResponse{}, errlib.NewAppErrorWithLog(errors.New("current password mismatch"), errlib.ErrCodeInvalidPassword)
+	}
+
+	if err := a.checkPasswordPolicy("new_password", request.Body.NewPassword, email); err != nil {
+		return PostPasswordChange400JSONResponse{}, err
+	}
+
+	hashPassword, err := a.PasswordHasher.Hash(request.Body.NewPassword)
+	if err != nil {
+		return PostPasswordChange500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeInternalServer)
+	}
+
+	tx, err := a.Db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return PostPasswordChange500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBTransaction)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE auth_credentials
+		SET password_hash = $1
+		WHERE user_id = $2 AND provider = 'local';
+	`, hashPassword, userID); err != nil {
+		return PostPasswordChange500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBTransaction)
+	}
+
+	// outstanding reset links were issued for the old password
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE password_reset_tokens
+		SET used_at = CURRENT_TIMESTAMP
+		WHERE user_id = $1 AND used_at IS NULL;
+	`, userID); err != nil {
+		return PostPasswordChange500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBTransaction)
+	}
+
+	var revoked []sessionAccessToken
+	if request.Body.RevokeOtherSessions == nil || *request.Body.RevokeOtherSessions {
+		rows, err := tx.QueryContext(ctx, `
+			UPDATE user_sessions
+			SET is_valid = 0
+			WHERE user_id = $1 AND id != $2 AND is_valid = 1
+			RETURNING access_token_jti, access_token_expires_at;
+		`, userID, sessionID)
+		if err != nil {
+			return PostPasswordChange500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBTransaction)
+		}
+		if revoked, err = scanSessionAccessTokens(rows); err != nil {
+			return PostPasswordChange500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBTransaction)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return PostPasswordChange500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBTransaction)
+	}
+	a.revokeAccessTokens(ctx, revoked)
+
+	resp := newLogoutResponse("password changed", len(revoked))
+
+	return PostPasswordChange200JSONResponse(resp), nil
+}
+
+func (a *AuthImpl) PostPasswordForgot(ctx context.Context, request PostPasswordForgotRequestObject) (PostPasswordForgotResponseObject, error) {
+
+	// same response whether the email is registered or not, to avoid account enumeration
+	resp := common.BaseSuccessResponse{
+		Message:    "if the email is registered, a password reset link has been sent",
+		StatusCode: http.StatusOK,
+	}
+
+	var userID int64
+	err := a.Db.DB.QueryRowContext(ctx, `
+		SELECT u.id FROM users u
+		WHERE u.email = $1 order by created_at desc limit 1;
+	`, request.Body.Email).Scan(&userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return PostPasswordForgot200JSONResponse(resp), nil
+		}
+		return PostPasswordForgot500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBQuery)
+	}
+
+	token, err := jwt.GenerateOpaqueToken()
+	if err != nil {
+		return PostPasswordForgot500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeInternalServer)
+	}
+
+	if _, err := a.Db.DB.ExecContext(ctx, `
+		INSERT INTO password_reset_tokens (user_id, token_hash, expires_at)
+		VALUES ($1, $2, $3)
+	`, userID, a.Jwt.HashToken(token), time.Now().Add(passwordResetTokenTTL)); err != nil {
+		return PostPasswordForgot500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBQuery)
+	}
+
+	link := fmt.Sprintf("%s/reset-password?token=%s", strings.TrimRight(a.AppBaseURL, "/"), url.QueryEscape(token))
+	msg := mailer.Message{
+		To:      request.Body.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Use the link below to reset your password. It expires in %d minutes.\n\n%s\n\n"+
+			"If you did not request a password reset, you can ignore this email.\n",
+			int(passwordResetTokenTTL.Minutes()), link),
+	}
+	if err := a.Mailer.Send(ctx, msg); err != nil {
+		// not reported to the client, the response must not reveal whether the email exists
+		log.Printf("failed to send password reset email to user %d: %v", userID, err)
+	}
+
+	return PostPasswordForgot200JSONResponse(resp), nil
+}
+
+func (a *AuthImpl) PostPasswordReset(ctx context.Context, request PostPasswordResetRequestObject) (PostPasswordResetResponseObject, error) {
+
+	tokenHash := a.Jwt.HashToken(request.Body.Token)
+
+	var (
+		resetID   int64
+		userID    int64
+		expiresAt time.Time
+		usedAt    sql.NullTime
+	)
+	err := a.Db.DB.QueryRowContext(ctx, `
+		SELECT id, user_id, expires_at, used_at
+		FROM password_reset_tokens
+		WHERE token_hash = $1;
+	`, tokenHash).Scan(&resetID, &userID, &expiresAt, &usedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return PostPasswordReset400JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeInvalidResetToken)
+		}
+		return PostPasswordReset500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBQuery)
+	}
+
+	if usedAt.Valid {
+		return PostPasswordReset400JSONResponse{}, errlib.NewAppErrorWithLog(errors.New("reset token already used"), errlib.ErrCodeInvalidResetToken)
+	}
+
+	if time.Now().After(expiresAt) {
+		return PostPasswordReset400JSONResponse{}, errlib.NewAppErrorWithLog(errors.New("expired reset token"), errlib.ErrCodeInvalidResetToken)
+	}
+
+	var email string
+	if err := a.Db.DB.QueryRowContext(ctx, `
+		SELECT email FROM users WHERE id = $1;
+	`, userID).Scan(&email); err != nil {
+		return PostPasswordReset500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBQuery)
+	}
+
+	if err := a.checkPasswordPolicy("new_password", request.Body.NewPassword, email); err != nil {
+		return PostPasswordReset400JSONResponse{}, err
+	}
+
+	hashPassword, err := a.PasswordHasher.Hash(request.Body.NewPassword)
+	if err != nil {
+		return PostPasswordReset500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeInternalServer)
+	}
+
+	tx, err := a.Db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return PostPasswordReset500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBTransaction)
+	}
+	defer tx.Rollback()
+
+	// consume token, only one concurrent request can use the same token
+	result, err := tx.ExecContext(ctx, `
+		UPDATE password_reset_tokens
+		SET used_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND used_at IS NULL;
+	`, resetID)
+	if err != nil {
+		return PostPasswordReset500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBTransaction)
+	}
+
+	consumed, err := result.RowsAffected()
+	if err != nil {
+		return PostPasswordReset500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBTransaction)
+	}
+	if consumed == 0 {
+		return PostPasswordReset400JSONResponse{}, errlib.NewAppErrorWithLog(errors.New("reset token already used"), errlib.ErrCodeInvalidResetToken)
+	}
+
+	// other outstanding reset links of the user are no longer usable
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE password_reset_tokens
+		SET used_at = CURRENT_TIMESTAMP
+		WHERE user_id = $1 AND used_at IS NULL;
+	`, userID); err != nil {
+		return PostPasswordReset500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBTransaction)
+	}
+
+	// user without local credential (social login) gets one
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO auth_credentials (user_id, provider, password_hash)
+		VALUES ($1, 'local', $2)
+		ON CONFLICT(user_id, provider) DO UPDATE SET password_hash = excluded.password_hash;
+	`, userID, hashPassword); err != nil {
+		return PostPasswordReset500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBTransaction)
+	}
+
+	// revoke all sessions and their access tokens, whoever knew the old password is logged out
+	rows, err := tx.QueryContext(ctx, `
+		UPDATE user_sessions
+		SET is_valid = 0
+		WHERE user_id = $1 AND is_valid = 1
+		RETURNING access_token_jti, access_token_expires_at;
+	`, userID)
+	if err != nil {
+		return PostPasswordReset500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBTransaction)
+	}
+	revoked, err := scanSessionAccessTokens(rows)
+	if err != nil {
+		return PostPasswordReset500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBTransaction)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return PostPasswordReset500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBTransaction)
+	}
+	a.revokeAccessTokens(ctx, revoked)
+
+	resp := common.BaseSuccessResponse{
+		Message:    "password has been reset, please login again",
+		StatusCode: http.StatusOK,
+	}
+
+	return PostPasswordReset200JSONResponse(resp), nil
+}
+
+// policy violations are returned as field errors of the request field
+func (a *AuthImpl) checkPasswordPolicy(field, password, email string) error {
+	violations, err := a.PasswordPolicy.Validate(password, email)
+	if err != nil {
+		return errlib.NewAppErrorWithLog(err, errlib.ErrCodeInternalServer)
+	}
+	if len(violations) > 0 {
+		return errlib.NewAppError(errlib.ErrCodePasswordPolicy).WithFieldErrors(field, violations...)
+	}
+	return nil
+}
+
+// replace hash of a verified password, failure only delays the upgrade to the next login
+func (a *AuthImpl) upgradePasswordHash(ctx context.Context, userID, oldHash, password string) {
+	newHash, err := a.PasswordHasher.Hash(password)
+	if err != nil {
+		log.Printf("failed to rehash password of user %s: %v", userID, err)
+		return
+	}
+
+	// skipped when the password was changed concurrently
+	if _, err := a.Db.DB.ExecContext(ctx, `
+		UPDATE auth_credentials
+		SET password_hash = $1
+		WHERE user_id = $2 AND provider = 'local' AND password_hash = $3;
+	`, newHash, userID, oldHash); err != nil {
+		log.Printf("failed to store rehashed password of user %s: %v", userID, err)
+	}
+}
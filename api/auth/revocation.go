@@ -0,0 +1,62 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"oapi-to-rest/pkg/jwt"
+	"time"
+
+	gojwt "github.com/golang-jwt/jwt/v5"
+)
+
+// access token of a session, stored so it can be revoked together with the session
+type sessionAccessToken struct {
+	jti       sql.NullString
+	expiresAt sql.NullTime
+}
+
+// jti and expiry of a freshly signed token
+func accessTokenOf(claims gojwt.MapClaims) sessionAccessToken {
+	var token sessionAccessToken
+	if jti, ok := claims[jwt.TokenIDClaim].(string); ok {
+		token.jti = sql.NullString{String: jti, Valid: true}
+	}
+	// set as unix seconds by TokenManager, not yet round-tripped through json
+	if exp, ok := claims["exp"].(int64); ok {
+		token.expiresAt = sql.NullTime{Time: time.Unix(exp, 0), Valid: true}
+	}
+	return token
+}
+
+// rows of UPDATE user_sessions ... RETURNING access_token_jti, access_token_expires_at
+func scanSessionAccessTokens(rows *sql.Rows) ([]sessionAccessToken, error) {
+	defer rows.Close()
+
+	var tokens []sessionAccessToken
+	for rows.Next() {
+		var token sessionAccessToken
+		if err := rows.Scan(&token.jti, &token.expiresAt); err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, token)
+	}
+	return tokens, rows.Err()
+}
+
+// put access tokens of invalidated sessions on the revocation list. failures are only logged,
+// the sessions are already invalidated and the bearer middleware rejects their tokens anyway
+func (a *AuthImpl) revokeAccessTokens(ctx context.Context, tokens []sessionAccessToken) {
+	if a.Revocations == nil {
+		return
+	}
+
+	for _, token := range tokens {
+		if !token.jti.Valid || !token.expiresAt.Valid {
+			continue
+		}
+		if err := a.Revocations.Revoke(ctx, token.jti.String, token.expiresAt.Time); err != nil {
+			log.Printf("failed to revoke access token %s, err: %v", token.jti.String, err)
+		}
+	}
+}
@@ -0,0 +1,250 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"oapi-to-rest/pkg/errlib"
+	"oapi-to-rest/pkg/middleware"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	PermissionRolesRead   = "roles:read"
+	PermissionRolesManage = "roles:manage"
+
+	auditEventRoleGrant  = "role.grant"
+	auditEventRoleRevoke = "role.revoke"
+)
+
+// permissions required by operation id, enforced by middleware.RequireOperationPermissions
+var OperationPermissions = map[string][]string{
+	"GetRoles":                   {PermissionRolesRead},
+	"GetUsersUserIdRoles":        {PermissionRolesRead},
+	"PutUsersUserIdRolesRole":    {PermissionRolesManage},
+	"DeleteUsersUserIdRolesRole": {PermissionRolesManage},
+}
+
+func (a *AuthImpl) GetRoles(ctx context.Context, request GetRolesRequestObject) (GetRolesResponseObject, error) {
+
+	rows, err := a.Db.DB.QueryContext(ctx, `
+		SELECT r.name, r.description, rp.permission
+		FROM roles r
+		LEFT JOIN role_permissions rp ON rp.role = r.name
+		ORDER BY r.name, rp.permission;
+	`)
+	if err != nil {
+		return GetRoles500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBQuery)
+	}
+	defer rows.Close()
+
+	roles := []Role{}
+	for rows.Next() {
+		var (
+			name        string
+			description string
+			permission  sql.NullString
+		)
+		if err := rows.Scan(&name, &description, &permission); err != nil {
+			return GetRoles500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBQuery)
+		}
+
+		if len(roles) == 0 || roles[len(roles)-1].Name != name {
+			roles = append(roles, Role{Name: name, Description: optionalString(description), Permissions: []string{}})
+		}
+		if permission.Valid {
+			role := &roles[len(roles)-1]
+			role.Permissions = append(role.Permissions, permission.String)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return GetRoles500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBQuery)
+	}
+
+	resp := RoleListResponse{
+		Data:       roles,
+		Message:    "success get roles",
+		StatusCode: http.StatusOK,
+	}
+
+	return GetRoles200JSONResponse(resp), nil
+}
+
+func (a *AuthImpl) GetUsersUserIdRoles(ctx context.Context, request GetUsersUserIdRolesRequestObject) (GetUsersUserIdRolesResponseObject, error) {
+
+	if err := a.checkUserExists(ctx, request.UserId); err != nil {
+		return GetUsersUserIdRoles404JSONResponse{}, err
+	}
+
+	data, err := a.userRolesOf(ctx, request.UserId)
+	if err != nil {
+		return GetUsersUserIdRoles500JSONResponse{}, err
+	}
+
+	return GetUsersUserIdRoles200JSONResponse(newUserRolesResponse("success get user roles", data)), nil
+}
+
+// grant is idempotent, the user gets the role's permissions with the next login or refresh
+func (a *AuthImpl) PutUsersUserIdRolesRole(ctx context.Context, request PutUsersUserIdRolesRoleRequestObject) (PutUsersUserIdRolesRoleResponseObject, error) {
+
+	principal, ok := middleware.PrincipalFromContext(ctx)
+	if !ok {
+		return PutUsersUserIdRolesRole401JSONResponse{}, errlib.NewAppErrorWithLog(errors.New("missing principal"), errlib.ErrCodeUnauthorized)
+	}
+
+	if err := a.checkUserExists(ctx, request.UserId); err != nil {
+		return PutUsersUserIdRolesRole404JSONResponse{}, err
+	}
+
+	var exists int
+	err := a.Db.DB.QueryRowContext(ctx, `
+		SELECT 1 FROM roles WHERE name = $1;
+	`, request.Role).Scan(&exists)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return PutUsersUserIdRolesRole404JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeRoleNotFound)
+		}
+		return PutUsersUserIdRolesRole500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBQuery)
+	}
+
+	tx, err := a.Db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return PutUsersUserIdRolesRole500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBTransaction)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO user_roles (user_id, role)
+		VALUES ($1, $2)
+		ON CONFLICT(user_id, role) DO NOTHING;
+	`, request.UserId, request.Role)
+	if err != nil {
+		return PutUsersUserIdRolesRole500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBTransaction)
+	}
+
+	if granted, _ := result.RowsAffected(); granted > 0 {
+		if err := a.auditRoleChange(ctx, tx, auditEventRoleGrant, principal, request.UserId, request.Role); err != nil {
+			return PutUsersUserIdRolesRole500JSONResponse{}, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return PutUsersUserIdRolesRole500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBTransaction)
+	}
+
+	data, err := a.userRolesOf(ctx, request.UserId)
+	if err != nil {
+		return PutUsersUserIdRolesRole500JSONResponse{}, err
+	}
+
+	return PutUsersUserIdRolesRole200JSONResponse(newUserRolesResponse("role granted", data)), nil
+}
+
+// revoked permissions stay in tokens issued before until they expire or are refreshed
+func (a *AuthImpl) DeleteUsersUserIdRolesRole(ctx context.Context, request DeleteUsersUserIdRolesRoleRequestObject) (DeleteUsersUserIdRolesRoleResponseObject, error) {
+
+	principal, ok := middleware.PrincipalFromContext(ctx)
+	if !ok {
+		return DeleteUsersUserIdRolesRole401JSONResponse{}, errlib.NewAppErrorWithLog(errors.New("missing principal"), errlib.ErrCodeUnauthorized)
+	}
+
+	// role managers cannot lock themselves out
+	if strconv.FormatInt(request.UserId, 10) == principal.UserID {
+		return DeleteUsersUserIdRolesRole403JSONResponse{}, errlib.NewAppErrorWithLog(errors.New("revoking own role"), errlib.ErrCodeForbidden)
+	}
+
+	if err := a.checkUserExists(ctx, request.UserId); err != nil {
+		return DeleteUsersUserIdRolesRole404JSONResponse{}, err
+	}
+
+	tx, err := a.Db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return DeleteUsersUserIdRolesRole500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBTransaction)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		DELETE FROM user_roles
+		WHERE user_id = $1 AND role = $2;
+	`, request.UserId, request.Role)
+	if err != nil {
+		return DeleteUsersUserIdRolesRole500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBTransaction)
+	}
+
+	revoked, err := result.RowsAffected()
+	if err != nil {
+		return DeleteUsersUserIdRolesRole500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBTransaction)
+	}
+	if revoked == 0 {
+		return DeleteUsersUserIdRolesRole404JSONResponse{}, errlib.NewAppErrorWithLog(errors.New("role "+request.Role+" not assigned"), errlib.ErrCodeRoleNotFound)
+	}
+
+	if err := a.auditRoleChange(ctx, tx, auditEventRoleRevoke, principal, request.UserId, request.Role); err != nil {
+		return DeleteUsersUserIdRolesRole500JSONResponse{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return DeleteUsersUserIdRolesRole500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBTransaction)
+	}
+
+	data, err := a.userRolesOf(ctx, request.UserId)
+	if err != nil {
+		return DeleteUsersUserIdRolesRole500JSONResponse{}, err
+	}
+
+	return DeleteUsersUserIdRolesRole200JSONResponse(newUserRolesResponse("role revoked", data)), nil
+}
+
+func (a *AuthImpl) checkUserExists(ctx context.Context, userID int64) error {
+	var exists int
+	err := a.Db.DB.QueryRowContext(ctx, `
+		SELECT 1 FROM users WHERE id = $1;
+	`, userID).Scan(&exists)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errlib.NewAppErrorWithLog(err, errlib.ErrCodeUserNotFound)
+		}
+		return errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBQuery)
+	}
+	return nil
+}
+
+func (a *AuthImpl) userRolesOf(ctx context.Context, userID int64) (UserRoles, error) {
+
+	id := strconv.FormatInt(userID, 10)
+	roles, err := a.userRoles(ctx, id)
+	if err != nil {
+		return UserRoles{}, err
+	}
+	permissions, err := a.userPermissions(ctx, id)
+	if err != nil {
+		return UserRoles{}, err
+	}
+
+	return UserRoles{UserId: userID, Roles: roles, Permissions: permissions}, nil
+}
+
+// role is kept as reason of the audit entry
+func (a *AuthImpl) auditRoleChange(ctx context.Context, tx *sql.Tx, event string, actor *middleware.Principal, targetID int64, role string) error {
+
+	ginCtx, _ := ctx.(*gin.Context)
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO audit_log (event, actor_user_id, target_user_id, session_id, reason, ip_address, user_agent)
+		VALUES ($1, $2, $3, $4, $5, $6, $7);
+	`, event, actor.UserID, targetID, actor.SessionID, role, ginCtx.ClientIP(), ginCtx.Request.UserAgent()); err != nil {
+		return errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBTransaction)
+	}
+	return nil
+}
+
+func newUserRolesResponse(message string, data UserRoles) UserRolesResponse {
+	return UserRolesResponse{
+		Data:       data,
+		Message:    message,
+		StatusCode: http.StatusOK,
+	}
+}
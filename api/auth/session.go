@@ -0,0 +1,345 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"oapi-to-rest/pkg/errlib"
+	"oapi-to-rest/pkg/helper"
+	"oapi-to-rest/pkg/jwt"
+	"oapi-to-rest/pkg/middleware"
+	"oapi-to-rest/pkg/rbac"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	gojwt "github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+func (a *AuthImpl) GetSessions(ctx context.Context, request GetSessionsRequestObject) (GetSessionsResponseObject, error) {
+
+	principal, ok := middleware.PrincipalFromContext(ctx)
+	if !ok {
+		return GetSessions401JSONResponse{}, errlib.NewAppErrorWithLog(errors.New("missing principal"), errlib.ErrCodeUnauthorized)
+	}
+
+	userID := principal.UserID
+	currentSessionID := principal.SessionID
+
+	rows, err := a.Db.DB.QueryContext(ctx, `
+		SELECT us.id, us.user_agent, us.ip_address, us.created_at, us.expires_at, us.impersonator_id
+		FROM user_sessions us
+		WHERE us.user_id = $1 AND us.is_valid = 1
+		ORDER BY us.created_at DESC;
+	`, userID)
+	if err != nil {
+		return GetSessions500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBQuery)
+	}
+	defer rows.Close()
+
+	now := time.Now()
+	sessions := []Session{}
+
+	for rows.Next() {
+		var (
+			id        string
+			userAgent sql.NullString
+			ipAddress sql.NullString
+			createdAt sql.NullTime
+			expiresAt sql.NullTime
+
+			impersonatorID sql.NullString
+		)
+		if err := rows.Scan(&id, &userAgent, &ipAddress, &createdAt, &expiresAt, &impersonatorID); err != nil {
+			return GetSessions500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBQuery)
+		}
+
+		// skip session with expired refresh token
+		if expiresAt.Valid && now.After(expiresAt.Time) {
+			continue
+		}
+
+		session := Session{
+			Id:        id,
+			IsCurrent: id == currentSessionID,
+		}
+		if userAgent.Valid {
+			session.UserAgent = &userAgent.String
+		}
+		if ipAddress.Valid {
+			session.IpAddress = &ipAddress.String
+		}
+		if createdAt.Valid {
+			session.CreatedAt = &createdAt.Time
+		}
+		if expiresAt.Valid {
+			session.ExpiresAt = &expiresAt.Time
+		}
+		if impersonatorID.Valid {
+			impersonated := true
+			session.IsImpersonation = &impersonated
+		}
+
+		sessions = append(sessions, session)
+	}
+
+	if err := rows.Err(); err != nil {
+		return GetSessions500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBQuery)
+	}
+
+	resp := SessionListResponse{
+		Data:       sessions,
+		Message:    "success get active sessions",
+		StatusCode: http.StatusOK,
+	}
+
+	return GetSessions200JSONResponse(resp), nil
+}
+
+func (a *AuthImpl) DeleteSessionsId(ctx context.Context, request DeleteSessionsIdRequestObject) (DeleteSessionsIdResponseObject, error) {
+
+	principal, ok := middleware.PrincipalFromContext(ctx)
+	if !ok {
+		return DeleteSessionsId401JSONResponse{}, errlib.NewAppErrorWithLog(errors.New("missing principal"), errlib.ErrCodeUnauthorized)
+	}
+
+	userID := principal.UserID
+
+	// only sessions owned by current user can be revoked
+	rows, err := a.Db.DB.QueryContext(ctx, `
+		UPDATE user_sessions
+		SET is_valid = 0
+		WHERE id = $1 AND user_id = $2 AND is_valid = 1
+		RETURNING access_token_jti, access_token_expires_at;
+	`, request.Id, userID)
+	if err != nil {
+		return DeleteSessionsId500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBQuery)
+	}
+
+	tokens, err := scanSessionAccessTokens(rows)
+	if err != nil {
+		return DeleteSessionsId500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBQuery)
+	}
+	a.revokeAccessTokens(ctx, tokens)
+
+	if len(tokens) == 0 {
+		return DeleteSessionsId404JSONResponse{}, errlib.NewAppError(errlib.ErrCodeSessionNotFound)
+	}
+
+	resp := newLogoutResponse("success revoke session", len(tokens))
+
+	return DeleteSessionsId200JSONResponse(resp), nil
+}
+
+const (
+	// lifetime of refresh token and its session row
+	refreshTokenTTL = 7 * 24 * time.Hour
+
+	// SESSION_LIMIT_POLICY rejecting logins over the limit, any other value evicts the oldest session
+	sessionLimitReject = "reject"
+)
+
+type issuedSession struct {
+	token        string
+	refreshToken string
+
+	// session is limited to mfa enrollment
+	mfaEnrollment bool
+}
+
+// create session of an authenticated user, a login starts a new refresh token family
+func (a *AuthImpl) createSession(ctx context.Context, userID, email string) (issuedSession, error) {
+
+	ginCtx, _ := ctx.(*gin.Context)
+	ip := ginCtx.ClientIP()
+	ua := ginCtx.Request.UserAgent()
+	deviceClass := helper.UserAgentClass(ua)
+	ctx = requestContext(ctx)
+
+	if err := a.enforceSessionLimits(ctx, userID, deviceClass); err != nil {
+		return issuedSession{}, err
+	}
+
+	sessionID := uuid.NewString()
+	familyID := uuid.NewString()
+
+	claims, err := a.buildUserClaims(ctx, userID, email, sessionID)
+	if err != nil {
+		return issuedSession{}, err
+	}
+
+	token, err := a.Jwt.GenerateJWT(claims)
+	if err != nil {
+		return issuedSession{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeInternalServer)
+	}
+
+	refreshToken, err := a.Jwt.GenerateRefreshToken()
+	if err != nil {
+		return issuedSession{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeInternalServer)
+	}
+
+	// store session with hashed tokens
+	accessToken := accessTokenOf(claims)
+	if _, err = a.Db.DB.ExecContext(ctx, `
+		INSERT INTO user_sessions (id, family_id, user_id, access_token, access_token_jti, access_token_expires_at, refresh_token, user_agent, device_class, ip_address, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11);
+	`, sessionID, familyID, userID, a.Jwt.HashToken(token), accessToken.jti, accessToken.expiresAt, a.Jwt.HashToken(refreshToken), ua, deviceClass, ip, time.Now().Add(refreshTokenTTL)); err != nil {
+		return issuedSession{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBTransaction)
+	}
+
+	enrollment, _ := claims[jwt.MFAEnrollmentClaim].(bool)
+	return issuedSession{token: token, refreshToken: refreshToken, mfaEnrollment: enrollment}, nil
+}
+
+// make room for a new session of the user, or reject it, according to SESSION_LIMIT_POLICY
+func (a *AuthImpl) enforceSessionLimits(ctx context.Context, userID, deviceClass string) error {
+
+	limits := []struct {
+		max         int
+		deviceClass string
+	}{
+		{max: a.Cfg.MaxSessionsPerUser},
+		{max: a.Cfg.MaxSessionsPerDeviceClass, deviceClass: deviceClass},
+	}
+
+	for _, limit := range limits {
+		if limit.max <= 0 {
+			continue
+		}
+
+		// impersonation sessions belong to the admin, they do not take a seat of the user
+		rows, err := a.Db.DB.QueryContext(ctx, `
+			SELECT id, expires_at FROM user_sessions
+			WHERE user_id = $1 AND is_valid = 1 AND impersonator_id IS NULL
+				AND ($2 = '' OR device_class = $2)
+			ORDER BY created_at ASC;
+		`, userID, limit.deviceClass)
+		if err != nil {
+			return errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBQuery)
+		}
+
+		now := time.Now()
+		var sessionIDs []string
+		for rows.Next() {
+			var (
+				id        string
+				expiresAt sql.NullTime
+			)
+			if err := rows.Scan(&id, &expiresAt); err != nil {
+				rows.Close()
+				return errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBQuery)
+			}
+
+			// session with expired refresh token is already gone for the user
+			if expiresAt.Valid && now.After(expiresAt.Time) {
+				continue
+			}
+			sessionIDs = append(sessionIDs, id)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBQuery)
+		}
+
+		excess := len(sessionIDs) - limit.max + 1
+		if excess <= 0 {
+			continue
+		}
+
+		if a.Cfg.SessionLimitPolicy == sessionLimitReject {
+			return errlib.NewAppErrorWithLog(fmt.Errorf("user %s reached session limit %d", userID, limit.max), errlib.ErrCodeSessionLimit)
+		}
+
+		// evict oldest sessions and their access tokens
+		for _, id := range sessionIDs[:excess] {
+			rows, err := a.Db.DB.QueryContext(ctx, `
+				UPDATE user_sessions
+				SET is_valid = 0
+				WHERE id = $1
+				RETURNING access_token_jti, access_token_expires_at;
+			`, id)
+			if err != nil {
+				return errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBQuery)
+			}
+			tokens, err := scanSessionAccessTokens(rows)
+			if err != nil {
+				return errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBQuery)
+			}
+			a.revokeAccessTokens(ctx, tokens)
+		}
+		log.Printf("evicted %d oldest sessions of user %s to respect session limit %d", excess, userID, limit.max)
+	}
+
+	return nil
+}
+
+// access token claims from current user data, bound to the given session
+func (a *AuthImpl) buildUserClaims(ctx context.Context, userID, email, sessionID string) (gojwt.MapClaims, error) {
+
+	roles, err := a.userRoles(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	permissions, err := a.userPermissions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	claims := a.Jwt.CreateUserClaims(userID, "", email, roles, permissions)
+	claims[jwt.SessionIDClaim] = sessionID
+
+	// token of user that must use mfa but has not enabled it is limited to enrollment
+	if a.requiresMFA(roles) {
+		enabled, err := a.hasTOTPEnabled(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		if !enabled {
+			claims[jwt.MFAEnrollmentClaim] = true
+		}
+	}
+
+	return claims, nil
+}
+
+func (a *AuthImpl) userRoles(ctx context.Context, userID string) ([]string, error) {
+	roles, err := rbac.UserRoles(ctx, a.Db, userID)
+	if err != nil {
+		return nil, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBQuery)
+	}
+	return roles, nil
+}
+
+func (a *AuthImpl) userPermissions(ctx context.Context, userID string) ([]string, error) {
+	permissions, err := rbac.UserPermissions(ctx, a.Db, userID)
+	if err != nil {
+		return nil, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBQuery)
+	}
+	return permissions, nil
+}
+
+func newLoginResponse(message, email string, session issuedSession) LoginResponse {
+	resp := LoginResponse{
+		Data: &struct {
+			Email                 *string "json:\"email,omitempty\""
+			MfaEnrollmentRequired *bool   "json:\"mfa_enrollment_required,omitempty\""
+			MfaRequired           *bool   "json:\"mfa_required,omitempty\""
+			MfaToken              *string "json:\"mfa_token,omitempty\""
+			RefreshToken          *string "json:\"refresh_token,omitempty\""
+			Token                 *string "json:\"token,omitempty\""
+		}{Email: &email},
+		Message:    message,
+		StatusCode: http.StatusOK,
+	}
+	if session.token != "" {
+		resp.Data.Token = &session.token
+		resp.Data.RefreshToken = &session.refreshToken
+	}
+	if session.mfaEnrollment {
+		resp.Data.MfaEnrollmentRequired = &session.mfaEnrollment
+	}
+	return resp
+}
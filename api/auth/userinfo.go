@@ -0,0 +1,64 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"oapi-to-rest/pkg/errlib"
+	"oapi-to-rest/pkg/middleware"
+	"strings"
+)
+
+// OIDC userinfo, claims are read from the users table so they reflect changes made after the token was issued
+func (a *AuthImpl) GetUserinfo(ctx context.Context, request GetUserinfoRequestObject) (GetUserinfoResponseObject, error) {
+
+	principal, ok := middleware.PrincipalFromContext(ctx)
+	if !ok {
+		return GetUserinfo401JSONResponse{}, errlib.NewAppErrorWithLog(errors.New("missing principal"), errlib.ErrCodeUnauthorized)
+	}
+
+	userID := principal.UserID
+
+	var (
+		email         string
+		firstName     sql.NullString
+		lastName      sql.NullString
+		emailVerified bool
+	)
+	err := a.Db.DB.QueryRowContext(ctx, `
+		SELECT email, first_name, last_name, email_verified FROM users
+		WHERE id = $1 AND is_active = 1;
+	`, userID).Scan(&email, &firstName, &lastName, &emailVerified)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return GetUserinfo401JSONResponse{}, errlib.NewAppErrorWithLog(errors.New("token user no longer exists or is inactive"), errlib.ErrCodeUnauthorized)
+		}
+		return GetUserinfo500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeDBQuery)
+	}
+
+	roles, err := a.userRoles(ctx, userID)
+	if err != nil {
+		return GetUserinfo500JSONResponse{}, err
+	}
+	if roles == nil {
+		roles = []string{}
+	}
+
+	resp := GetUserinfo200JSONResponse{
+		Sub:           userID,
+		Email:         email,
+		EmailVerified: emailVerified,
+		Roles:         roles,
+	}
+	if firstName.String != "" {
+		resp.GivenName = &firstName.String
+	}
+	if lastName.String != "" {
+		resp.FamilyName = &lastName.String
+	}
+	if name := strings.TrimSpace(firstName.String + " " + lastName.String); name != "" {
+		resp.Name = &name
+	}
+
+	return resp, nil
+}
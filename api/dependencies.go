@@ -6,6 +6,10 @@ import (
 	"oapi-to-rest/pkg/env"
 	"oapi-to-rest/pkg/errlib"
 	"oapi-to-rest/pkg/jwt"
+	"oapi-to-rest/pkg/mailer"
+	"oapi-to-rest/pkg/oauth"
+	"oapi-to-rest/pkg/password"
+	"oapi-to-rest/pkg/revocation"
 
 	"github.com/jmoiron/sqlx"
 )
@@ -16,6 +20,12 @@ type Dependencies struct {
 
 	ErrorHandler *errlib.ErrorHandler
 	Jwt          *jwt.TokenManager
+	Mailer       mailer.Sender
+
+	OAuthProviders map[string]*oauth.Provider
+	PasswordPolicy *password.Policy
+	PasswordHasher *password.Hasher
+	Revocations    revocation.Store
 }
 
 func InitDependencies(cfg *env.Config) Dependencies {
@@ -25,14 +35,36 @@ func InitDependencies(cfg *env.Config) Dependencies {
 	// errorHandler
 	dep.ErrorHandler = errlib.NewErrorHandler(cfg.DebugMode)
 
-	tm, err := jwt.NewRSAJwtInit(&cfg.Jwt)
+	tm, err := jwt.NewJwtInit(&cfg.Jwt)
 	if err != nil {
-		log.Println("error init rsa jwt, err: %w", err)
-		tm = nil
+		log.Fatalf("error init jwt, mode: %s, err: %v", cfg.Jwt.Mode, err)
 	}
 
 	dep.Jwt = tm
 
+	// mailer
+	mail, err := mailer.New(cfg.Mail)
+	if err != nil {
+		log.Fatalf("error init mailer: %v", err)
+	}
+	dep.Mailer = mail
+
+	// social login providers
+	dep.OAuthProviders = map[string]*oauth.Provider{}
+	for name, providerCfg := range cfg.Auth.OAuthProviders {
+		dep.OAuthProviders[name] = oauth.NewProvider(providerCfg)
+	}
+
+	// password policy
+	dep.PasswordPolicy = password.NewPolicy(cfg.Auth.PasswordPolicy)
+
+	// password hasher
+	hasher, err := password.NewHasher(cfg.Auth.PasswordHash)
+	if err != nil {
+		log.Fatalf("error init password hasher: %v", err)
+	}
+	dep.PasswordHasher = hasher
+
 	// db
 	if cfg.InitSqlite {
 		dbcfg := db.SQLiteConfig{
@@ -60,5 +92,12 @@ func InitDependencies(cfg *env.Config) Dependencies {
 		dep.Sqlx = sqlx.NewDb(dep.DbSqlite.DB, "sqlite3")
 	}
 
+	// revoked access tokens
+	revocations, err := revocation.New(cfg.Auth.TokenRevocationStore, dep.DbSqlite)
+	if err != nil {
+		log.Fatalf("error init token revocation store: %v", err)
+	}
+	dep.Revocations = revocations
+
 	return dep
 }
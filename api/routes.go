@@ -3,21 +3,36 @@ package api
 import (
 	"oapi-to-rest/api/auth"
 	"oapi-to-rest/api/user"
+	"oapi-to-rest/api/wellknown"
+	"oapi-to-rest/pkg/db"
 	"oapi-to-rest/pkg/env"
 	"oapi-to-rest/pkg/errlib"
+	"oapi-to-rest/pkg/jwt"
 	"oapi-to-rest/pkg/middleware"
+	"oapi-to-rest/pkg/revocation"
 
 	"github.com/gin-gonic/gin"
 )
 
+// auth routes, also announced in the well-known metadata
+const authBasePath = "/api/v1/auth"
+
 // represents the API server with all dependencies
 type Server struct {
 	Config *env.Config
 	Router *gin.Engine
 
+	// used by authorization middleware to check session validity
+	DbSqlite *db.SQLite
+
+	// used by authorization middleware to verify tokens
+	Jwt         *jwt.TokenManager
+	Revocations revocation.Store
+
 	// add dependencies here (DB clients, services, etc.)
-	User user.ServerInterface
-	Auth auth.ServerInterface
+	User      user.ServerInterface
+	Auth      auth.ServerInterface
+	WellKnown wellknown.ServerInterface
 
 	// standardized error handler
 	ErrorHandler errlib.ErrorHandler
@@ -31,15 +46,43 @@ func NewServer(cfg *env.Config) *Server {
 	userImpl := user.UserImpl{Sqlx: dep.Sqlx}
 	userStrictHandler := user.NewStrictHandler(&userImpl, []user.StrictMiddlewareFunc{})
 
-	authImpl := auth.AuthImpl{Db: dep.DbSqlite, Jwt: dep.Jwt}
-	authStrictHandler := auth.NewStrictHandler(&authImpl, []auth.StrictMiddlewareFunc{})
+	authImpl := auth.AuthImpl{
+		Db:             dep.DbSqlite,
+		Jwt:            dep.Jwt,
+		Mailer:         dep.Mailer,
+		AppBaseURL:     cfg.AppBaseURL,
+		Cfg:            cfg.Auth,
+		OAuthProviders: dep.OAuthProviders,
+		PasswordPolicy: dep.PasswordPolicy,
+		PasswordHasher: dep.PasswordHasher,
+		Revocations:    dep.Revocations,
+	}
+	authStrictHandler := auth.NewStrictHandler(&authImpl, []auth.StrictMiddlewareFunc{
+		middleware.RequireOperationPermissions(auth.OperationPermissions),
+	})
+
+	wellKnownImpl := wellknown.WellKnownImpl{
+		Jwt: dep.Jwt,
+		Endpoints: wellknown.Endpoints{
+			Token:         authBasePath + "/oauth/token",
+			Introspection: authBasePath + "/oauth/introspect",
+			Revocation:    authBasePath + "/oauth/revoke",
+			Userinfo:      authBasePath + "/userinfo",
+		},
+	}
+	wellKnownStrictHandler := wellknown.NewStrictHandler(&wellKnownImpl, []wellknown.StrictMiddlewareFunc{})
 
 	return &Server{
 		Config: cfg,
 		Router: gin.New(),
 
-		User: userStrictHandler,
-		Auth: authStrictHandler,
+		DbSqlite:    dep.DbSqlite,
+		Jwt:         dep.Jwt,
+		Revocations: dep.Revocations,
+
+		User:      userStrictHandler,
+		Auth:      authStrictHandler,
+		WellKnown: wellKnownStrictHandler,
 
 		ErrorHandler: *dep.ErrorHandler,
 	}
@@ -56,15 +99,45 @@ func (s *Server) RegisterRoutes() {
 	api := s.Router.Group("api")
 	v1 := api.Group("v1")
 
-	userV1, authV1 := v1, v1.Group("auth")
+	userV1, authV1 := v1, s.Router.Group(authBasePath)
 
 	// authorization bearer middleware
-	mw := middleware.NewAuthenticationMiddleware(s.Config.Jwt.PublicKeyBase64)
+	mw := middleware.NewAuthenticationMiddleware(s.Jwt, s.DbSqlite)
+	mw.AllowDuringMFAEnrollment(
+		"/api/v1/auth/mfa/totp/setup",
+		"/api/v1/auth/mfa/totp/confirm",
+		"/api/v1/auth/logout",
+		"/api/v1/auth/logout-all",
+	)
+	mw.BlockDuringImpersonation(
+		"/api/v1/auth/impersonate",
+		"/api/v1/auth/password/change",
+		"/api/v1/auth/mfa/totp/setup",
+		"/api/v1/auth/mfa/totp/confirm",
+		"/api/v1/auth/logout-all",
+		"/api/v1/auth/sessions/:id",
+		"/api/v1/auth/api-keys",
+		"/api/v1/auth/api-keys/:id",
+		"/api/v1/auth/users/:user_id/roles/:role",
+	)
+	mw.EnableAPIKeys(s.Config.Jwt.TokenHashSecret)
+	mw.EnableRevocationList(s.Revocations)
+
+	// resource routes also accept X-API-Key, account management in auth stays bearer only
+	// user routes serve the caller's own account, client tokens have none
 	userOpts := user.GinServerOptions{
-		Middlewares: []user.MiddlewareFunc{mw.AuthorizationBearerJWT()},
+		Middlewares: []user.MiddlewareFunc{mw.AuthorizationBearerOrAPIKey(), middleware.RequireUserPrincipal()},
 	}
 	user.RegisterHandlersWithOptions(userV1, s.User, userOpts)
-	auth.RegisterHandlers(authV1, s.Auth)
+
+	// only operations with bearerAuth security in auth spec are protected, client tokens have no account to manage
+	authOpts := auth.GinServerOptions{
+		Middlewares: []auth.MiddlewareFunc{mw.SecuredOperationBearerJWT(), middleware.RequireUserPrincipal()},
+	}
+	auth.RegisterHandlersWithOptions(authV1, s.Auth, authOpts)
+
+	// public metadata at the root, outside of the versioned api
+	wellknown.RegisterHandlers(s.Router.Group(".well-known"), s.WellKnown)
 }
 
 func (s *Server) Start(addr string) error {
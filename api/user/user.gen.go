@@ -24,6 +24,7 @@ import (
 )
 
 const (
+	ApiKeyAuthScopes = "apiKeyAuth.Scopes"
 	BearerAuthScopes = "bearerAuth.Scopes"
 )
 
@@ -88,6 +89,8 @@ func (siw *ServerInterfaceWrapper) GetUser(c *gin.Context) {
 
 	c.Set(BearerAuthScopes, []string{})
 
+	c.Set(ApiKeyAuthScopes, []string{})
+
 	// Parameter object where we will unmarshal all parameters from the context
 	var params GetUserParams
 
@@ -189,6 +192,15 @@ func (response GetUser400JSONResponse) VisitGetUserResponse(w http.ResponseWrite
 	return json.NewEncoder(w).Encode(response)
 }
 
+type GetUser401JSONResponse externalRef0.StandardErrorResponse
+
+func (response GetUser401JSONResponse) VisitGetUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type GetUser500JSONResponse externalRef0.StandardErrorResponse
 
 func (response GetUser500JSONResponse) VisitGetUserResponse(w http.ResponseWriter) error {
@@ -247,19 +259,20 @@ func (sh *strictHandler) GetUser(ctx *gin.Context, params GetUserParams) {
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/8xWTW/zNgz+Kwa3o9OkH9vBtwL7QHcK1g07FEXA2IyjwpZUik6bFv7vgyQnThO389r3",
-	"8J5sS+JDUuTz0K+Qm9oaTVocZK/g8jXVGF7nWCqNQsXfjvhPctZoR37DsrHEoigcK1DQP5VQHRZ+ZFpB",
-	"Bj9Me+RpBzv1UNCmIFtLkAEy49Z/r1QlxBGPXM7KijIaMnBr85SgtZWiIulOJRYZaxJi2COZ5QPlAik8",
-	"T0oz6RaVFuIV5vTaeic2JhSAj7PIG2bSMscypLgyXKNEiJ+vejcesYwpWCzpVr2MPS5GsLrZ3dFYAx/P",
-	"OIP2+CoGVlII93+SPNWoKv/SnXfCSpfhMg1aNclNQSXpCT0L40SwjIVaQtaZtqGE7GShsabPIR3YezhV",
-	"jEl7DLAqIqBbYC5qc1Kwy4tP4u4BPXyFX8q+N28HC+cs5W6BVi1yU9dGL7gj5OJWUBfIxa/M5iOakqCq",
-	"3Gl0bQrkTcMWFoXyBMFqfmg9FJHSTlDnNIjoBKU5dHbY2KomJ1jbQUtRUg1jCmNOi9gXp5th4WRjkBWO",
-	"8oaVbG+9KMXbWRIy8XUj6/7rt12P/PHPX5BGZfRIcbfvmbWIjVVTemVCEDGHwLbkF1Oj0sn1/AZS2BC7",
-	"KGznZ7OzmY/cWNJoFWRwGZa8ssg6RDVtOrqWJP7hKxL066aADH4nCXT2Bp0eOsjujhU0MDQR06lnstyC",
-	"jxQyeGyI/Ufs2o7KXaL4Tlm3ITFPH2jTY1d7Roxx19PnKy73XhKvx++46rZ6LwWtsKkEsvN0jLKeDCX1",
-	"QolZJYT5+r/8hhEx7Hs2xvl9Cjuqh564mM3CvDJaSIeuCNMxD30xfXBxuPXuPprGwxM+tPLbhK+TSjnx",
-	"KfuOdL4uV98wjv8rbgMRLrFImB4bckGcfvq+ogs/IhqrJCjtGxEKjD2Un7t7X3PX1DXyNtI83nrypGS9",
-	"+weKXhzxZsf6hivIYIpWTTfn0N63/wYAAP//9vUGXdsJAAA=",
+	"H4sIAAAAAAAC/8xWS2/cNhD+KwTboza7TtIedDPQB9xcFnWLFjCMxaw0K01Akcxw5EQ29N8LktpH1kq6",
+	"TXrISRI5832c10c96cp13lm0EnT5pEPVYgfpdQ0NWRCs/wzIv2PwzgaMG56dRxbCZFaDQHySYJcWvmfc",
+	"6VJ/tzwiLyfYZYTSY6Fl8KhLDcwwxO8dGUHOeBgqJi/krC51aN17Bd4bwlpNVsoDQ4eCrA9IbvsWK9GF",
+	"/rBo3GJaJCvIO6jwaYwkPgeUgM+jqHpmtLKGJoW4c9yBZIgfXx9pImKTQ/DQ4C09XmouTsDc7HN0qUM8",
+	"z2UO43kqZlYKnfL/LHjsgEx8meyDMNkmJdOBp0XlamzQLvCDMCwEmlyorS4n1zGVkINsLHT4ZUgn/hGO",
+	"6kvCvgSY6gwYNlAJPTwr2KuXX4h7AIzwBr4q+qP7OFu44LEKG/C0qVzXObvhaSA3twK2Bq5/ZnafG1MU",
+	"IBOen24sNEbXtAV1TXFAwKxPvedORDYI2ApnEYOA9Kdkp41NHQaBzs96ComZxxSGCjdUz2+mhWcbs1MR",
+	"sOqZZLiNopSzA57e4HDdSxu/yOpStwh16oRcVP334np9s3iDw7Fbslek3yIw8t4/f/2y77Hf/vpDF1lZ",
+	"o1fePaK0Ij5XnezOpSByDtK0qp9cB2TV9fpGF/oBOWRhvHqxerGK1M6jBU+61K/SUlQmaVNUy34a9wYl",
+	"PmJFk/7d1LrUv6JEguQw6WnQ5d25AqcJV+Im9VXbmICUoXc98nBMUDLcBwqfaIshBRbHT4/FOdVhoi6h",
+	"Oxh/FeWBRUU9/wTVtHVkqXEHvRFdXhWXKPM5aaBHVG6nEKr233jTFTPPvbqE/L7Qe6lIPfFytYqPyllB",
+	"K7n3vaEq9cXybciX45Huc7f5/B9CauWPA75WhoLEkGNHhkI5awYlLaoKjEFWvTUYgmoYrGCdrUpGqGMJ",
+	"X/+PR/6vOjoTzBZqxfiuxyD5dFff0ul6C720jukRU/J++LaSl37JLBiV7pyP5Dhpz6mQ3t3H0TmV5rv7",
+	"2M+h7zrgIUtY7hX1nqTd/x9m3oD8sFe0no0u9RI8LR+u9Hg//jMA2C0A4fcKAAA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -2,8 +2,10 @@ package user
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"oapi-to-rest/pkg/errlib"
+	"oapi-to-rest/pkg/middleware"
 	"strconv"
 	"strings"
 
@@ -20,6 +22,9 @@ const (
 	defaultPage     int64 = 1
 	defaultPageSize int64 = 20
 	maxPageSize     int64 = 100
+
+	// list every user, callers without it only see themselves
+	PermissionUsersRead = "users:read"
 )
 
 type UserRow struct {
@@ -29,6 +34,11 @@ type UserRow struct {
 
 func (u *UserImpl) GetUser(ctx context.Context, request GetUserRequestObject) (GetUserResponseObject, error) {
 
+	principal, ok := middleware.PrincipalFromContext(ctx)
+	if !ok {
+		return GetUser401JSONResponse{}, errlib.NewAppErrorWithLog(errors.New("missing principal"), errlib.ErrCodeUnauthorized)
+	}
+
 	var result PaginatedUserResponse
 	params := request.Params
 
@@ -39,6 +49,12 @@ func (u *UserImpl) GetUser(ctx context.Context, request GetUserRequestObject) (G
 	var args []interface{}
 	argIndex := 1
 
+	if !principal.HasPermission(PermissionUsersRead) {
+		whereConditions = append(whereConditions, fmt.Sprintf("u.id = $%d", argIndex))
+		args = append(args, principal.UserID)
+		argIndex++
+	}
+
 	if params.Email != nil {
 		whereConditions = append(whereConditions, fmt.Sprintf("u.email = $%d", argIndex))
 		args = append(args, *params.Email)
@@ -0,0 +1,4 @@
+//go:generate go run github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen --config=cfg.yaml ../../specs/api/v1/wellknown.yaml
+
+package wellknown
+
@@ -0,0 +1,137 @@
+package wellknown
+
+import (
+	"context"
+	"errors"
+	"oapi-to-rest/pkg/errlib"
+	"oapi-to-rest/pkg/jwt"
+)
+
+const (
+	// verifiers fetch the key set again after this long, rotated keys must stay published at least as long
+	jwksCacheControl = "public, max-age=300"
+
+	metadataCacheControl = "public, max-age=3600"
+
+	jwksPath = "/.well-known/jwks.json"
+
+	// sub is the user id for every client
+	subjectTypePublic = "public"
+
+	// oauth endpoints authenticate registered clients with HTTP Basic
+	clientSecretBasic = "client_secret_basic"
+)
+
+// claims of access tokens and userinfo
+var supportedClaims = []string{
+	"iss", "sub", "aud", "exp", "iat", "nbf",
+	"email", "email_verified", "name", "given_name", "family_name", "roles",
+}
+
+// paths of the routes announced in the metadata, relative to the issuer
+type Endpoints struct {
+	Token         string
+	Introspection string
+	Revocation    string
+	Userinfo      string
+}
+
+type WellKnownImpl struct {
+	Jwt       *jwt.TokenManager
+	Endpoints Endpoints
+}
+
+var _ StrictServerInterface = (*WellKnownImpl)(nil)
+
+func (w *WellKnownImpl) GetJwksJson(ctx context.Context, request GetJwksJsonRequestObject) (GetJwksJsonResponseObject, error) {
+
+	if w.Jwt == nil {
+		return GetJwksJson500JSONResponse{}, errlib.NewAppErrorWithLog(errors.New("token manager is not initialized"), errlib.ErrCodeInternalServer)
+	}
+
+	keys, err := w.Jwt.JWKS()
+	if err != nil {
+		return GetJwksJson500JSONResponse{}, errlib.NewAppErrorWithLog(err, errlib.ErrCodeInternalServer)
+	}
+
+	resp := GetJwksJson200JSONResponse{
+		Body: JWKSet{Keys: make([]JWK, 0, len(keys))},
+	}
+	resp.Headers.CacheControl = jwksCacheControl
+
+	for _, key := range keys {
+		resp.Body.Keys = append(resp.Body.Keys, JWK{
+			Kty: key.Kty,
+			Kid: key.Kid,
+			Use: key.Use,
+			Alg: key.Alg,
+			N:   optional(key.N),
+			E:   optional(key.E),
+			Crv: optional(key.Crv),
+			X:   optional(key.X),
+			Y:   optional(key.Y),
+		})
+	}
+
+	return resp, nil
+}
+
+// OIDC discovery, endpoint urls are built from the issuer so it must be the public base url of this server
+func (w *WellKnownImpl) GetOpenidConfiguration(ctx context.Context, request GetOpenidConfigurationRequestObject) (GetOpenidConfigurationResponseObject, error) {
+
+	if w.Jwt == nil {
+		return GetOpenidConfiguration500JSONResponse{}, errlib.NewAppErrorWithLog(errors.New("token manager is not initialized"), errlib.ErrCodeInternalServer)
+	}
+
+	issuer := w.Jwt.Issuer()
+	resp := GetOpenidConfiguration200JSONResponse{
+		Body: OpenIDConfiguration{
+			Issuer:                           issuer,
+			JwksUri:                          issuer + jwksPath,
+			UserinfoEndpoint:                 issuer + w.Endpoints.Userinfo,
+			ResponseTypesSupported:           []string{},
+			SubjectTypesSupported:            []string{subjectTypePublic},
+			IdTokenSigningAlgValuesSupported: []string{w.Jwt.Algorithm()},
+			ClaimsSupported:                  supportedClaims,
+		},
+	}
+	resp.Headers.CacheControl = metadataCacheControl
+
+	return resp, nil
+}
+
+// RFC 8414 metadata, endpoint urls are built from the issuer so it must be the public base url of this server.
+// only client_credentials tokens are issued at the token endpoint, there is no authorization endpoint
+func (w *WellKnownImpl) GetOauthAuthorizationServer(ctx context.Context, request GetOauthAuthorizationServerRequestObject) (GetOauthAuthorizationServerResponseObject, error) {
+
+	if w.Jwt == nil {
+		return GetOauthAuthorizationServer500JSONResponse{}, errlib.NewAppErrorWithLog(errors.New("token manager is not initialized"), errlib.ErrCodeInternalServer)
+	}
+
+	issuer := w.Jwt.Issuer()
+	resp := GetOauthAuthorizationServer200JSONResponse{
+		Body: AuthorizationServerMetadata{
+			Issuer:                 issuer,
+			JwksUri:                issuer + jwksPath,
+			TokenEndpoint:          issuer + w.Endpoints.Token,
+			IntrospectionEndpoint:  issuer + w.Endpoints.Introspection,
+			RevocationEndpoint:     issuer + w.Endpoints.Revocation,
+			ResponseTypesSupported: []string{},
+			GrantTypesSupported:    []string{"client_credentials"},
+
+			TokenEndpointAuthMethodsSupported:         []string{clientSecretBasic},
+			IntrospectionEndpointAuthMethodsSupported: []string{clientSecretBasic},
+			RevocationEndpointAuthMethodsSupported:    []string{clientSecretBasic},
+		},
+	}
+	resp.Headers.CacheControl = metadataCacheControl
+
+	return resp, nil
+}
+
+func optional(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
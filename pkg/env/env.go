@@ -3,6 +3,10 @@ package env
 import (
 	"log"
 	"oapi-to-rest/pkg/jwt"
+	"oapi-to-rest/pkg/mailer"
+	"oapi-to-rest/pkg/oauth"
+	"oapi-to-rest/pkg/password"
+	"oapi-to-rest/pkg/revocation"
 	"os"
 	"strconv"
 	"strings"
@@ -21,7 +25,62 @@ type Config struct {
 	Env        string
 	DebugMode  bool
 
-	Jwt jwt.JwtConfig
+	// public url of the app, used to build links sent by email
+	AppBaseURL string
+
+	Jwt  jwt.JwtConfig
+	Mail mailer.MailConfig
+	Auth AuthConfig
+}
+
+type AuthConfig struct {
+	// new users must verify their email before login
+	RequireEmailVerification bool
+	EmailVerificationTTL     time.Duration
+
+	// minimum interval between two verification emails to the same user
+	EmailVerificationResendInterval time.Duration
+
+	// lifetime of a passwordless login link
+	MagicLinkTTL time.Duration
+
+	// base64 AES-256 key encrypting totp secrets at rest
+	MFAEncryptionKey string
+	MFAIssuer        string
+
+	// users with any of these roles must enable mfa
+	MFARequiredRoles []string
+
+	// lifetime of admin impersonation tokens, they cannot be refreshed
+	ImpersonationTTL time.Duration
+
+	// active sessions per user and per user agent class, 0 is unlimited
+	MaxSessionsPerUser        int
+	MaxSessionsPerDeviceClass int
+
+	// "evict_oldest" or "reject" when a login exceeds a session limit
+	SessionLimitPolicy string
+
+	// failed logins counted within window, per account and per client ip
+	LoginFailureWindow      time.Duration
+	LoginMaxFailuresAccount int
+	LoginMaxFailuresIP      int
+	LoginLockoutDuration    time.Duration
+
+	// account delay doubles from this value on each failure until lockout
+	LoginDelayBase time.Duration
+
+	// "sqlite" or "memory" store of revoked access tokens, memory is per process
+	TokenRevocationStore string
+
+	// social login providers by name
+	OAuthProviders map[string]oauth.ProviderConfig
+
+	// rules applied on register, password change and reset
+	PasswordPolicy password.PolicyConfig
+
+	// algorithm and cost of new password hashes, older hashes are upgraded on login
+	PasswordHash password.HasherConfig
 }
 
 type Environment int
@@ -56,17 +115,106 @@ func LoadConfig(path string) (*Config, error) {
 		SqlitePath: getEnv("SQLITE_PATH", "data/app.db").String(),
 		Env:        getEnv("ENV", "").String(),
 		DebugMode:  getEnv("DEBUG_MODE", "").Bool(),
+		AppBaseURL: getEnv("APP_BASE_URL", "http://localhost:8080").String(),
 
 		Jwt: jwt.JwtConfig{
+			Mode:             getEnv("JWT_MODE", jwt.RS256).String(),
+			Secret:           getEnv("JWT_SECRET", "").String(),
 			PrivateKeyBase64: getEnv("JWT_PRIVATE_KEY", "").String(),
 			PublicKeyBase64:  getEnv("JWT_PUBLIC_KEY", "").String(),
 			ExpiresInSecond:  getEnv("JWT_EXPIRES_SECONDS", "").DurationInSecond(),
+			KeyID:            getEnv("JWT_KEY_ID", "").String(),
+			VerificationKeys: getEnv("JWT_VERIFICATION_KEYS", "").StringSlice(","),
+			TokenHashSecret:  getEnv("TOKEN_HASH_SECRET", "").String(),
+		},
+
+		Mail: mailer.MailConfig{
+			Driver:    getEnv("MAIL_DRIVER", mailer.DriverStdout).String(),
+			OutboxDir: getEnv("MAIL_OUTBOX_DIR", "data/outbox").String(),
+			From:      getEnv("MAIL_FROM", "no-reply@localhost").String(),
+		},
+
+		Auth: AuthConfig{
+			RequireEmailVerification:        getEnv("REQUIRE_EMAIL_VERIFICATION", "false").Bool(),
+			EmailVerificationTTL:            getEnv("EMAIL_VERIFICATION_TTL", "24h").DurationInSecond(),
+			EmailVerificationResendInterval: getEnv("EMAIL_VERIFICATION_RESEND_INTERVAL", "60s").DurationInSecond(),
+			MagicLinkTTL:                    getEnv("MAGIC_LINK_TTL", "15m").DurationInSecond(),
+			TokenRevocationStore:            getEnv("TOKEN_REVOCATION_STORE", revocation.DriverSQLite).String(),
+			MFAEncryptionKey:                getEnv("MFA_ENCRYPTION_KEY", "").String(),
+			MFAIssuer:                       getEnv("APP_NAME", "oapirest-boilerplate").String(),
+			MFARequiredRoles:                getEnv("MFA_REQUIRED_ROLES", "admin").StringSlice(","),
+			ImpersonationTTL:                getEnv("IMPERSONATION_TTL", "15m").DurationInSecond(),
+			MaxSessionsPerUser:              getEnv("MAX_SESSIONS_PER_USER", "").IntDefault(0),
+			MaxSessionsPerDeviceClass:       getEnv("MAX_SESSIONS_PER_DEVICE_CLASS", "").IntDefault(0),
+			SessionLimitPolicy:              getEnv("SESSION_LIMIT_POLICY", "evict_oldest").String(),
+			LoginFailureWindow:              getEnv("LOGIN_FAILURE_WINDOW", "15m").DurationInSecond(),
+			LoginMaxFailuresAccount:         getEnv("LOGIN_MAX_FAILURES_ACCOUNT", "").IntDefault(5),
+			LoginMaxFailuresIP:              getEnv("LOGIN_MAX_FAILURES_IP", "").IntDefault(20),
+			LoginLockoutDuration:            getEnv("LOGIN_LOCKOUT_DURATION", "15m").DurationInSecond(),
+			LoginDelayBase:                  getEnv("LOGIN_DELAY_BASE", "1s").DurationInSecond(),
+
+			PasswordPolicy: password.PolicyConfig{
+				MinLength:     getEnv("PASSWORD_MIN_LENGTH", "").IntDefault(8),
+				MaxBytes:      getEnv("PASSWORD_MAX_BYTES", "").IntDefault(password.BcryptMaxBytes),
+				RequireUpper:  getEnv("PASSWORD_REQUIRE_UPPER", "false").Bool(),
+				RequireLower:  getEnv("PASSWORD_REQUIRE_LOWER", "false").Bool(),
+				RequireDigit:  getEnv("PASSWORD_REQUIRE_DIGIT", "false").Bool(),
+				RequireSymbol: getEnv("PASSWORD_REQUIRE_SYMBOL", "false").Bool(),
+				DisallowEmail: getEnv("PASSWORD_DISALLOW_EMAIL", "true").Bool(),
+				BreachedDir:   getEnv("PASSWORD_BREACHED_DIR", "").String(),
+			},
+
+			PasswordHash: password.HasherConfig{
+				Algorithm:         getEnv("PASSWORD_HASH_ALGORITHM", password.AlgorithmArgon2id).String(),
+				BcryptCost:        getEnv("PASSWORD_BCRYPT_COST", "").IntDefault(10),
+				Argon2Memory:      uint32(getEnv("PASSWORD_ARGON2_MEMORY_KB", "").IntDefault(64 * 1024)),
+				Argon2Iterations:  uint32(getEnv("PASSWORD_ARGON2_ITERATIONS", "").IntDefault(3)),
+				Argon2Parallelism: uint8(getEnv("PASSWORD_ARGON2_PARALLELISM", "").IntDefault(2)),
+			},
 		},
 	}
 
+	cfg.Auth.OAuthProviders = loadOAuthProviders(cfg.AppBaseURL)
+
+	// tokens are issued by this server unless configured otherwise, and meant for it
+	cfg.Jwt.Issuer = getEnv("JWT_ISSUER", strings.TrimRight(cfg.AppBaseURL, "/")).String()
+	cfg.Jwt.Audience = getEnv("JWT_AUDIENCE", cfg.Jwt.Issuer).String()
+	cfg.Jwt.Leeway = getEnv("JWT_LEEWAY", "30s").DurationInSecond()
+	cfg.Jwt.RequiredClaims = getEnv("JWT_REQUIRED_CLAIMS", "exp,iat,sub,jti").StringSlice(",")
+
 	return cfg, nil
 }
 
+// providers listed in OAUTH_PROVIDERS, each configured with OAUTH_<NAME>_* variables
+func loadOAuthProviders(appBaseURL string) map[string]oauth.ProviderConfig {
+
+	providers := map[string]oauth.ProviderConfig{}
+	for _, name := range getEnv("OAUTH_PROVIDERS", "").StringSlice(",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+
+		prefix := "OAUTH_" + strings.ToUpper(name) + "_"
+		providers[name] = oauth.ProviderConfig{
+			Name:         name,
+			ClientID:     getEnv(prefix+"CLIENT_ID", "").String(),
+			ClientSecret: getEnv(prefix+"CLIENT_SECRET", "").String(),
+			AuthURL:      getEnv(prefix+"AUTH_URL", "").String(),
+			TokenURL:     getEnv(prefix+"TOKEN_URL", "").String(),
+			UserInfoURL:  getEnv(prefix+"USERINFO_URL", "").String(),
+			RedirectURL:  getEnv(prefix+"REDIRECT_URL", strings.TrimRight(appBaseURL, "/")+"/api/v1/auth/oauth/"+name+"/callback").String(),
+			Scopes:       getEnv(prefix+"SCOPES", "openid email profile").StringSlice(" "),
+			SubjectField: getEnv(prefix+"SUBJECT_FIELD", "sub").String(),
+			EmailField:   getEnv(prefix+"EMAIL_FIELD", "email").String(),
+			TrustEmail:   getEnv(prefix+"TRUST_EMAIL", "false").Bool(),
+			Issuer:       getEnv(prefix+"ISSUER", "").String(),
+			JWKSURL:      getEnv(prefix+"JWKS_URL", "").String(),
+		}
+	}
+	return providers
+}
+
 func getEnv(key, defaultValue string) EnvVariable {
 	if stringVal, exists := os.LookupEnv(key); exists {
 		return EnvVariable{stringVal: stringVal}
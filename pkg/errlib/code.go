@@ -5,11 +5,42 @@ const (
 	ErrCodeUserNotFound           string = "USER_NOT_FOUND"
 	ErrCodeInvalidEmailOrPassword string = "INVALID_EMAIL_OR_PASSWORD"
 	ErrCodeTokenExpired           string = "TOKEN_EXPIRED"
+	ErrCodeTokenNotYetValid       string = "TOKEN_NOT_YET_VALID"
+	ErrCodeTokenRevoked           string = "TOKEN_REVOKED"
+	ErrCodeInvalidToken           string = "INVALID_TOKEN"
+	ErrCodeInvalidTokenSignature  string = "INVALID_TOKEN_SIGNATURE"
+	ErrCodeInvalidTokenIssuer     string = "INVALID_TOKEN_ISSUER"
+	ErrCodeInvalidTokenAudience   string = "INVALID_TOKEN_AUDIENCE"
 	ErrCodeInvalidRefreshToken    string = "INVALID_REFRESH_TOKEN"
+	ErrCodeSessionNotFound        string = "SESSION_NOT_FOUND"
+	ErrCodeSessionLimit           string = "SESSION_LIMIT_REACHED"
+	ErrCodeAPIKeyNotFound         string = "API_KEY_NOT_FOUND"
+	ErrCodeInvalidResetToken      string = "INVALID_RESET_TOKEN"
+	ErrCodeInvalidMagicLink       string = "INVALID_MAGIC_LINK"
+	ErrCodeInvalidPassword        string = "INVALID_CURRENT_PASSWORD"
+	ErrCodePasswordPolicy         string = "PASSWORD_POLICY_VIOLATION"
+	ErrCodeEmailNotVerified       string = "EMAIL_NOT_VERIFIED"
+	ErrCodeInvalidVerifyToken     string = "INVALID_VERIFICATION_TOKEN"
+	ErrCodeInvalidMFAToken        string = "INVALID_MFA_TOKEN"
+	ErrCodeInvalidMFACode         string = "INVALID_MFA_CODE"
+	ErrCodeMFAAlreadyEnabled      string = "MFA_ALREADY_ENABLED"
+	ErrCodeAccountLocked          string = "ACCOUNT_LOCKED"
+	ErrCodeImpersonationDenied    string = "IMPERSONATION_NOT_ALLOWED"
+	ErrCodeOAuthProviderNotFound  string = "OAUTH_PROVIDER_NOT_FOUND"
+	ErrCodeInvalidOAuthState      string = "INVALID_OAUTH_STATE"
+	ErrCodeOAuthDenied            string = "OAUTH_DENIED"
+	ErrCodeOAuthProvider          string = "OAUTH_PROVIDER_ERROR"
+	ErrCodeOAuthEmailUnverified   string = "OAUTH_EMAIL_UNVERIFIED"
+	ErrCodeOAuthAccountConflict   string = "OAUTH_ACCOUNT_CONFLICT"
+	ErrCodeInvalidClient          string = "INVALID_CLIENT"
+	ErrCodeUnsupportedGrantType   string = "UNSUPPORTED_GRANT_TYPE"
+	ErrCodeInvalidScope           string = "INVALID_SCOPE"
+	ErrCodeUnauthorizedClient     string = "UNAUTHORIZED_CLIENT"
 	ErrCodeInvalidInput           string = "INVALID_INPUT"
 	ErrCodeAccessDenied           string = "ACCESS_DENIED"
 	ErrCodeUnauthorized           string = "UNAUTHORIZED"
 	ErrCodeForbidden              string = "FORBIDDEN"
+	ErrCodeRoleNotFound           string = "ROLE_NOT_FOUND"
 	ErrCodeInternalServer         string = "INTERNAL_SERVER_ERROR"
 	ErrCodeRateLimited            string = "RATE_LIMITED"
 	ErrCodeValidation             string = "VALIDATION_ERROR"
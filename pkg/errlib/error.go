@@ -3,7 +3,10 @@ package errlib
 import (
 	"fmt"
 	"log"
+	"math"
 	"net/http"
+	"strconv"
+	"time"
 )
 
 type AppError struct {
@@ -11,6 +14,12 @@ type AppError struct {
 	Message string
 	Status  int
 	Details map[string]interface{}
+
+	// extra response headers, e.g. Retry-After
+	Headers http.Header
+
+	// field-level validation errors, always returned to the client
+	FieldErrors map[string][]string
 }
 
 func NewAppErrorWithLog(err error, code string) *AppError {
@@ -58,6 +67,29 @@ func (e *AppError) Error() string {
 	return e.Message
 }
 
+// set Retry-After header in whole seconds, rounded up
+func (e *AppError) WithRetryAfter(d time.Duration) *AppError {
+	return e.WithHeader("Retry-After", strconv.Itoa(int(math.Ceil(d.Seconds()))))
+}
+
+// set extra response header, e.g. WWW-Authenticate
+func (e *AppError) WithHeader(key, value string) *AppError {
+	if e.Headers == nil {
+		e.Headers = http.Header{}
+	}
+	e.Headers.Set(key, value)
+	return e
+}
+
+// add validation messages for a request field
+func (e *AppError) WithFieldErrors(field string, messages ...string) *AppError {
+	if e.FieldErrors == nil {
+		e.FieldErrors = map[string][]string{}
+	}
+	e.FieldErrors[field] = append(e.FieldErrors[field], messages...)
+	return e
+}
+
 // common error without detail function
 func ErrUserNotFound() *AppError            { return NewAppError(ErrCodeUserNotFound) }
 func ErrInvalidEmailrOrPassword() *AppError { return NewAppError(ErrCodeInvalidEmailOrPassword) }
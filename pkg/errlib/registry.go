@@ -19,6 +19,161 @@ var errorRegistry = map[string]AppError{
 		Message: "Token has expired",
 		Status:  http.StatusUnauthorized,
 	},
+	ErrCodeTokenNotYetValid: {
+		Code:    ErrCodeTokenNotYetValid,
+		Message: "Token is not valid yet",
+		Status:  http.StatusUnauthorized,
+	},
+	ErrCodeTokenRevoked: {
+		Code:    ErrCodeTokenRevoked,
+		Message: "Token has been revoked",
+		Status:  http.StatusUnauthorized,
+	},
+	ErrCodeInvalidToken: {
+		Code:    ErrCodeInvalidToken,
+		Message: "Invalid token",
+		Status:  http.StatusUnauthorized,
+	},
+	ErrCodeInvalidTokenSignature: {
+		Code:    ErrCodeInvalidTokenSignature,
+		Message: "Token signature is invalid",
+		Status:  http.StatusUnauthorized,
+	},
+	ErrCodeInvalidTokenIssuer: {
+		Code:    ErrCodeInvalidTokenIssuer,
+		Message: "Token was issued by an untrusted issuer",
+		Status:  http.StatusUnauthorized,
+	},
+	ErrCodeInvalidTokenAudience: {
+		Code:    ErrCodeInvalidTokenAudience,
+		Message: "Token is not intended for this audience",
+		Status:  http.StatusUnauthorized,
+	},
+	ErrCodeInvalidRefreshToken: {
+		Code:    ErrCodeInvalidRefreshToken,
+		Message: "Invalid or expired refresh token",
+		Status:  http.StatusUnauthorized,
+	},
+	ErrCodeInvalidMagicLink: {
+		Code:    ErrCodeInvalidMagicLink,
+		Message: "Invalid or expired login link, please request a new one",
+		Status:  http.StatusBadRequest,
+	},
+	ErrCodeInvalidPassword: {
+		Code:    ErrCodeInvalidPassword,
+		Message: "Current password is incorrect",
+		Status:  http.StatusBadRequest,
+	},
+	ErrCodePasswordPolicy: {
+		Code:    ErrCodePasswordPolicy,
+		Message: "Password does not meet the password policy",
+		Status:  http.StatusBadRequest,
+	},
+	ErrCodeEmailNotVerified: {
+		Code:    ErrCodeEmailNotVerified,
+		Message: "Email address is not verified",
+		Status:  http.StatusForbidden,
+	},
+	ErrCodeInvalidVerifyToken: {
+		Code:    ErrCodeInvalidVerifyToken,
+		Message: "Invalid or expired email verification token",
+		Status:  http.StatusBadRequest,
+	},
+	ErrCodeAccountLocked: {
+		Code:    ErrCodeAccountLocked,
+		Message: "Too many failed login attempts, try again later",
+		Status:  http.StatusTooManyRequests,
+	},
+	ErrCodeImpersonationDenied: {
+		Code:    ErrCodeImpersonationDenied,
+		Message: "This user cannot be impersonated",
+		Status:  http.StatusForbidden,
+	},
+	ErrCodeOAuthProviderNotFound: {
+		Code:    ErrCodeOAuthProviderNotFound,
+		Message: "OAuth provider not configured",
+		Status:  http.StatusNotFound,
+	},
+	ErrCodeInvalidOAuthState: {
+		Code:    ErrCodeInvalidOAuthState,
+		Message: "Invalid or expired OAuth state, please start login again",
+		Status:  http.StatusBadRequest,
+	},
+	ErrCodeOAuthDenied: {
+		Code:    ErrCodeOAuthDenied,
+		Message: "Authorization was denied at the provider",
+		Status:  http.StatusBadRequest,
+	},
+	ErrCodeOAuthProvider: {
+		Code:    ErrCodeOAuthProvider,
+		Message: "OAuth provider request failed",
+		Status:  http.StatusBadGateway,
+	},
+	ErrCodeOAuthEmailUnverified: {
+		Code:    ErrCodeOAuthEmailUnverified,
+		Message: "Provider account has no verified email",
+		Status:  http.StatusBadRequest,
+	},
+	ErrCodeOAuthAccountConflict: {
+		Code:    ErrCodeOAuthAccountConflict,
+		Message: "Account is already linked to another identity of this provider",
+		Status:  http.StatusConflict,
+	},
+	ErrCodeInvalidClient: {
+		Code:    ErrCodeInvalidClient,
+		Message: "Client authentication failed",
+		Status:  http.StatusUnauthorized,
+	},
+	ErrCodeUnsupportedGrantType: {
+		Code:    ErrCodeUnsupportedGrantType,
+		Message: "Grant type is not supported",
+		Status:  http.StatusBadRequest,
+	},
+	ErrCodeInvalidScope: {
+		Code:    ErrCodeInvalidScope,
+		Message: "Requested scope is not allowed for this client",
+		Status:  http.StatusBadRequest,
+	},
+	ErrCodeUnauthorizedClient: {
+		Code:    ErrCodeUnauthorizedClient,
+		Message: "Client is not allowed to revoke this token",
+		Status:  http.StatusBadRequest,
+	},
+	ErrCodeInvalidMFAToken: {
+		Code:    ErrCodeInvalidMFAToken,
+		Message: "Invalid or expired MFA token, please login again",
+		Status:  http.StatusUnauthorized,
+	},
+	ErrCodeInvalidMFACode: {
+		Code:    ErrCodeInvalidMFACode,
+		Message: "Invalid MFA code",
+		Status:  http.StatusUnauthorized,
+	},
+	ErrCodeMFAAlreadyEnabled: {
+		Code:    ErrCodeMFAAlreadyEnabled,
+		Message: "MFA is already enabled",
+		Status:  http.StatusBadRequest,
+	},
+	ErrCodeInvalidResetToken: {
+		Code:    ErrCodeInvalidResetToken,
+		Message: "Invalid or expired password reset token",
+		Status:  http.StatusBadRequest,
+	},
+	ErrCodeSessionNotFound: {
+		Code:    ErrCodeSessionNotFound,
+		Message: "Session not found",
+		Status:  http.StatusNotFound,
+	},
+	ErrCodeSessionLimit: {
+		Code:    ErrCodeSessionLimit,
+		Message: "Maximum number of active sessions reached, log out from another device first",
+		Status:  http.StatusConflict,
+	},
+	ErrCodeAPIKeyNotFound: {
+		Code:    ErrCodeAPIKeyNotFound,
+		Message: "API key not found",
+		Status:  http.StatusNotFound,
+	},
 	ErrCodeUnauthorized: {
 		Code:    ErrCodeUnauthorized,
 		Message: "Authentication required",
@@ -34,6 +189,11 @@ var errorRegistry = map[string]AppError{
 		Message: "Insufficient permissions",
 		Status:  http.StatusForbidden,
 	},
+	ErrCodeRoleNotFound: {
+		Code:    ErrCodeRoleNotFound,
+		Message: "Role not found",
+		Status:  http.StatusNotFound,
+	},
 
 	// validation/parsing
 	ErrCodeInvalidInput: {
@@ -101,6 +101,16 @@ func (eh *ErrorHandler) HandleError(r *http.Request, err error) ErrorResponse {
 		errResp.Errors = appErr.Details
 	}
 
+	// field errors are meant for the client, not only for debugging
+	if len(appErr.FieldErrors) > 0 {
+		if errResp.Errors == nil {
+			errResp.Errors = map[string]interface{}{}
+		}
+		for field, messages := range appErr.FieldErrors {
+			errResp.Errors[field] = messages
+		}
+	}
+
 	// force include details if client 4xx errors
 	if status >= 400 && status < 500 && appErr.Details != nil {
 		errResp.Detail = fmt.Sprintf("%v", appErr.Details)
@@ -114,6 +124,11 @@ func (eh *ErrorHandler) HandleAndSendErrorResponse(w http.ResponseWriter, r *htt
 	errResp := eh.HandleError(r, err)
 
 	// response headers
+	if appErr, ok := err.(*AppError); ok {
+		for key, values := range appErr.Headers {
+			w.Header()[key] = values
+		}
+	}
 	w.Header().Set("Content-Type", "application/problem+json")
 	w.WriteHeader(errResp.Status)
 
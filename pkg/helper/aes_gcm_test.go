@@ -0,0 +1,94 @@
+package helper
+
+import (
+	"bytes"
+	"encoding/base64"
+	"testing"
+)
+
+func testAESKey(t *testing.T, fill byte) []byte {
+	t.Helper()
+	key, err := LoadAESKey(base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{fill}, 32)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func TestLoadAESKey(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		ok    bool
+	}{
+		{"32 bytes", base64.StdEncoding.EncodeToString(make([]byte, 32)), true},
+		{"16 bytes", base64.StdEncoding.EncodeToString(make([]byte, 16)), false},
+		{"not base64", "not base64!", false},
+		{"empty", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadAESKey(tt.value)
+			if (err == nil) != tt.ok {
+				t.Errorf("err = %v, want ok %v", err, tt.ok)
+			}
+		})
+	}
+}
+
+func TestAESGCMRoundTrip(t *testing.T) {
+	key := testAESKey(t, 1)
+
+	sealed, err := EncryptAESGCM(key, "JBSWY3DPEHPK3PXP")
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := EncryptAESGCM(key, "JBSWY3DPEHPK3PXP")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// a fresh nonce per seal
+	if sealed == again {
+		t.Error("sealing the same secret twice gave the same ciphertext")
+	}
+
+	opened, err := DecryptAESGCM(key, sealed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opened != "JBSWY3DPEHPK3PXP" {
+		t.Errorf("opened %q", opened)
+	}
+}
+
+func TestAESGCMRejectsTampering(t *testing.T) {
+	key := testAESKey(t, 1)
+	sealed, err := EncryptAESGCM(key, "JBSWY3DPEHPK3PXP")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	raw, _ := base64.StdEncoding.DecodeString(sealed)
+	flipped := bytes.Clone(raw)
+	flipped[len(flipped)-1] ^= 0x01
+
+	tests := []struct {
+		name    string
+		key     []byte
+		encoded string
+	}{
+		{"flipped ciphertext bit", key, base64.StdEncoding.EncodeToString(flipped)},
+		{"other key", testAESKey(t, 2), sealed},
+		{"shorter than nonce", key, base64.StdEncoding.EncodeToString(raw[:4])},
+		{"not base64", key, "not base64!"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := DecryptAESGCM(tt.key, tt.encoded); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
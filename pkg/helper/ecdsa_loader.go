@@ -0,0 +1,46 @@
+package helper
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/x509"
+	"errors"
+)
+
+// PKCS8 PEM encoded P-256 private key, base64 encoded
+func LoadECDSAPrivateKey(s string) (*ecdsa.PrivateKey, error) {
+	block, err := decodeBase64PEM(s)
+	if err != nil {
+		return nil, err
+	}
+
+	priv, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	pk, ok := priv.(*ecdsa.PrivateKey)
+	if !ok || pk.Curve != elliptic.P256() {
+		return nil, errors.New("private key is not an ECDSA P-256 key")
+	}
+	return pk, nil
+}
+
+// PKIX PEM encoded P-256 public key, base64 encoded
+func LoadECDSAPublicKey(s string) (*ecdsa.PublicKey, error) {
+	block, err := decodeBase64PEM(s)
+	if err != nil {
+		return nil, err
+	}
+
+	pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	pk, ok := pub.(*ecdsa.PublicKey)
+	if !ok || pk.Curve != elliptic.P256() {
+		return nil, errors.New("public key is not an ECDSA P-256 key")
+	}
+	return pk, nil
+}
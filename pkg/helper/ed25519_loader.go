@@ -0,0 +1,45 @@
+package helper
+
+import (
+	"crypto/ed25519"
+	"crypto/x509"
+	"errors"
+)
+
+// PKCS8 PEM encoded Ed25519 private key, base64 encoded
+func LoadEd25519PrivateKey(s string) (ed25519.PrivateKey, error) {
+	block, err := decodeBase64PEM(s)
+	if err != nil {
+		return nil, err
+	}
+
+	priv, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	pk, ok := priv.(ed25519.PrivateKey)
+	if !ok {
+		return nil, errors.New("private key is not an Ed25519 key")
+	}
+	return pk, nil
+}
+
+// PKIX PEM encoded Ed25519 public key, base64 encoded
+func LoadEd25519PublicKey(s string) (ed25519.PublicKey, error) {
+	block, err := decodeBase64PEM(s)
+	if err != nil {
+		return nil, err
+	}
+
+	pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	pk, ok := pub.(ed25519.PublicKey)
+	if !ok {
+		return nil, errors.New("public key is not an Ed25519 key")
+	}
+	return pk, nil
+}
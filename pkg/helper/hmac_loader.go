@@ -0,0 +1,14 @@
+package helper
+
+import "fmt"
+
+// HS256 needs a key at least as long as the hash output
+const minHMACSecretLength = 32
+
+// raw HMAC secret, used as is
+func LoadHMACSecret(s string) ([]byte, error) {
+	if len(s) < minHMACSecretLength {
+		return nil, fmt.Errorf("invalid secret length %d, expected at least %d bytes", len(s), minHMACSecretLength)
+	}
+	return []byte(s), nil
+}
@@ -0,0 +1,22 @@
+package helper
+
+import (
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+)
+
+// decode base64 encoded PEM, keys are passed base64 encoded to fit in a single env variable
+func decodeBase64PEM(s string) (*pem.Block, error) {
+	decodedBytes, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("error: %w", err)
+	}
+
+	block, _ := pem.Decode(decodedBytes)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+	return block, nil
+}
@@ -0,0 +1,27 @@
+package helper
+
+import "strings"
+
+const (
+	DeviceClassMobile  = "mobile"
+	DeviceClassTablet  = "tablet"
+	DeviceClassDesktop = "desktop"
+	DeviceClassOther   = "other"
+)
+
+// coarse device class of a user agent, unknown agents (cli, bots, sdks) are "other"
+func UserAgentClass(userAgent string) string {
+	ua := strings.ToLower(userAgent)
+	switch {
+	case strings.Contains(ua, "ipad") || strings.Contains(ua, "tablet") ||
+		(strings.Contains(ua, "android") && !strings.Contains(ua, "mobile")):
+		return DeviceClassTablet
+	case strings.Contains(ua, "mobi") || strings.Contains(ua, "iphone"):
+		return DeviceClassMobile
+	case strings.Contains(ua, "windows") || strings.Contains(ua, "macintosh") ||
+		strings.Contains(ua, "x11") || strings.Contains(ua, "cros"):
+		return DeviceClassDesktop
+	default:
+		return DeviceClassOther
+	}
+}
@@ -1,10 +1,46 @@
 package jwt
 
-import "github.com/golang-jwt/jwt/v5"
+import (
+	"encoding/json"
 
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// typed view of the claims minted by the token manager
 type CustomClaims struct {
-	UserID   string `json:"user_id,omitempty"`
-	Username string `json:"username,omitempty"`
-	Email    string `json:"email,omitempty"`
+	UserID      string   `json:"user_id,omitempty"`
+	Username    string   `json:"username,omitempty"`
+	Email       string   `json:"email,omitempty"`
+	Roles       []string `json:"roles,omitempty"`
+	Permissions []string `json:"permissions,omitempty"`
+
+	// space separated, set on api key and client_credentials principals
+	Scope string `json:"scope,omitempty"`
+
+	SessionID     string       `json:"sid,omitempty"`
+	ClientID      string       `json:"client_id,omitempty"`
+	APIKeyID      string       `json:"api_key_id,omitempty"`
+	MFAEnrollment bool         `json:"mfa_enroll,omitempty"`
+	Actor         *ActorClaims `json:"act,omitempty"`
 	jwt.RegisteredClaims
 }
+
+// admin acting as the subject of an impersonation token
+type ActorClaims struct {
+	Subject string `json:"sub"`
+	Email   string `json:"email,omitempty"`
+}
+
+// claims parsed from a token or built by CreateUserClaims, numbers may be float64 or int64
+func ParseCustomClaims(claims jwt.MapClaims) (*CustomClaims, error) {
+	raw, err := json.Marshal(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	var custom CustomClaims
+	if err := json.Unmarshal(raw, &custom); err != nil {
+		return nil, err
+	}
+	return &custom, nil
+}
@@ -1,64 +1,285 @@
 package jwt
 
 import (
+	"crypto/hmac"
 	"crypto/rand"
-	"crypto/rsa"
+	"crypto/sha256"
 	"encoding/hex"
 	"errors"
+	"fmt"
 	"oapi-to-rest/pkg/helper"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 )
 
 const (
 	RS256 = "RS256"
+	ES256 = "ES256"
+	EdDSA = "EdDSA"
+	HS256 = "HS256"
+
+	// unique id of every minted token, key of the revocation list
+	TokenIDClaim = "jti"
+
+	// claim holding user_sessions.id the token belongs to
+	SessionIDClaim = "sid"
+
+	// claim marking token of a user that must enable mfa before using the api
+	MFAEnrollmentClaim = "mfa_enroll"
+
+	// claims of a principal authenticated by api key instead of a token
+	APIKeyIDClaim = "api_key_id"
+	ScopeClaim    = "scope"
+
+	// RFC 9068 claim of the client a token was issued to, tokens of the client_credentials grant carry no user_id
+	ClientIDClaim = "client_id"
+
+	// permissions granted by the roles of a user when the token was issued
+	PermissionsClaim = "permissions"
+
+	// RFC 8693 actor claim, set on tokens of an admin acting as another user
+	ActorClaim = "act"
 )
 
 type JwtConfig struct {
+	// signing algorithm: RS256, ES256, EdDSA or HS256
+	Mode string
+
+	// base64 PEM keys for RS256, ES256 and EdDSA
 	PrivateKeyBase64 string
 	PublicKeyBase64  string
-	ExpiresInSecond  time.Duration
+
+	// raw secret for HS256
+	Secret string
+
+	ExpiresInSecond time.Duration
+
+	// iss and aud claims of issued tokens, issuer is the base url of the discovery document.
+	// parsed tokens must carry the same values
+	Issuer   string
+	Audience string
+
+	// clock skew tolerated when checking exp, nbf and iat
+	Leeway time.Duration
+
+	// claims a token must carry to be accepted
+	RequiredClaims []string
+
+	// kid of the signing key, RFC 7638 thumbprint of the key when empty
+	KeyID string
+
+	// retired keys accepted for verification only, as "kid:key" with the key
+	// in the same format as JWT_PUBLIC_KEY (JWT_SECRET for HS256)
+	VerificationKeys []string
+
+	// server secret used to hash tokens before they are persisted
+	TokenHashSecret string
 }
 
 type TokenManager struct {
-	alg             string
-	privateKey      any
-	publicKey       any
+	alg string
+
+	// active key, stamped as kid header of issued tokens
+	kid        string
+	privateKey any
+
+	// keys accepted for verification by kid, the active one and retired ones
+	publicKeys map[string]any
+
+	// kids in publish order, active key first
+	kids []string
+
+	issuer   string
+	audience string
+
+	parserOptions  []jwt.ParserOption
+	requiredClaims []string
+
+	tokenHashSecret []byte
 	ExpiresInSecond time.Duration
 }
 
-func NewRSAJwtInit(config *JwtConfig) (*TokenManager, error) {
-	privKey, err := helper.LoadRSAPrivateKey(config.PrivateKeyBase64)
+// token manager for the algorithm in config mode, RS256 when mode is empty
+func NewJwtInit(config *JwtConfig) (*TokenManager, error) {
+	tokenHashSecret, err := helper.LoadHMACSecret(config.TokenHashSecret)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("token hash secret: %w", err)
 	}
-	pubKey, err := helper.LoadRSAPublicKey(config.PublicKeyBase64)
+
+	alg, err := ParseMode(config.Mode)
 	if err != nil {
 		return nil, err
 	}
-	return &TokenManager{
-		alg:             RS256,
-		privateKey:      privKey,
-		publicKey:       pubKey,
+
+	tm := &TokenManager{
+		alg:             alg,
+		publicKeys:      map[string]any{},
+		issuer:          strings.TrimRight(config.Issuer, "/"),
+		audience:        config.Audience,
+		tokenHashSecret: tokenHashSecret,
 		ExpiresInSecond: config.ExpiresInSecond,
-	}, nil
+	}
+
+	// applied wherever tokens are parsed
+	tm.parserOptions = []jwt.ParserOption{
+		jwt.WithValidMethods([]string{alg}),
+		jwt.WithLeeway(config.Leeway),
+		jwt.WithIssuedAt(),
+	}
+	if tm.issuer != "" {
+		tm.parserOptions = append(tm.parserOptions, jwt.WithIssuer(tm.issuer))
+	}
+	if tm.audience != "" {
+		tm.parserOptions = append(tm.parserOptions, jwt.WithAudience(tm.audience))
+	}
+	for _, claim := range config.RequiredClaims {
+		if claim = strings.TrimSpace(claim); claim != "" {
+			tm.requiredClaims = append(tm.requiredClaims, claim)
+		}
+	}
+
+	// symmetric, the same secret signs and verifies
+	var publicKey any
+	if alg == HS256 {
+		tm.privateKey, err = helper.LoadHMACSecret(config.Secret)
+		publicKey = tm.privateKey
+	} else {
+		if tm.privateKey, err = loadPrivateKey(alg, config.PrivateKeyBase64); err != nil {
+			return nil, err
+		}
+		publicKey, err = loadPublicKey(alg, config.PublicKeyBase64)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	tm.kid = config.KeyID
+	if tm.kid == "" {
+		if tm.kid, err = Thumbprint(publicKey); err != nil {
+			return nil, err
+		}
+	}
+	if err := tm.addVerificationKey(tm.kid, publicKey); err != nil {
+		return nil, err
+	}
+
+	// retired keys, tokens they signed stay valid until they expire
+	for _, entry := range config.VerificationKeys {
+		kid, value, ok := strings.Cut(strings.TrimSpace(entry), ":")
+		if !ok || kid == "" || value == "" {
+			return nil, fmt.Errorf("verification key must be kid:key, got %q", entry)
+		}
+
+		var key any
+		if alg == HS256 {
+			key, err = helper.LoadHMACSecret(value)
+		} else {
+			key, err = loadPublicKey(alg, value)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("verification key %s: %w", kid, err)
+		}
+		if err := tm.addVerificationKey(kid, key); err != nil {
+			return nil, err
+		}
+	}
+
+	return tm, nil
+}
+
+// algorithm of a JWT_MODE value, RSA256 is accepted for RS256
+func ParseMode(mode string) (string, error) {
+	switch strings.ToUpper(strings.TrimSpace(mode)) {
+	case "", "RS256", "RSA256":
+		return RS256, nil
+	case "ES256":
+		return ES256, nil
+	case "EDDSA", "ED25519":
+		return EdDSA, nil
+	case "HS256":
+		return HS256, nil
+	default:
+		return "", fmt.Errorf("unsupported jwt mode %q", mode)
+	}
+}
+
+func (tm *TokenManager) Algorithm() string {
+	return tm.alg
+}
+
+func (tm *TokenManager) Issuer() string {
+	return tm.issuer
+}
+
+func (tm *TokenManager) Audience() string {
+	return tm.audience
+}
+
+// kid stamped into issued tokens
+func (tm *TokenManager) KeyID() string {
+	return tm.kid
+}
+
+// parse and verify token with the key named by its kid header. only the configured algorithm,
+// issuer and audience are accepted, time claims are checked with the configured leeway.
+// errors wrap the jwt package sentinels (jwt.ErrTokenExpired, jwt.ErrTokenInvalidAudience, ...)
+func (tm *TokenManager) ParseJWT(tokenString string) (*jwt.Token, error) {
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+
+		// tokens issued before kids were stamped can only be signed by the active key
+		if kid == "" {
+			return tm.publicKeys[tm.kid], nil
+		}
+
+		key, ok := tm.publicKeys[kid]
+		if !ok {
+			return nil, fmt.Errorf("unknown signing key %q", kid)
+		}
+		return key, nil
+	}, tm.parserOptions...)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, jwt.ErrTokenInvalidClaims
+	}
+	for _, claim := range tm.requiredClaims {
+		if _, ok := claims[claim]; !ok {
+			return nil, fmt.Errorf("%w: %s", jwt.ErrTokenRequiredClaimMissing, claim)
+		}
+	}
+
+	return token, nil
 }
 
 func (tm *TokenManager) GenerateJWT(claims jwt.MapClaims) (string, error) {
+	return tm.GenerateJWTWithTTL(claims, tm.ExpiresInSecond)
+}
+
+// token with a lifetime other than the configured one, e.g. short-lived impersonation tokens.
+// claims gain exp and a fresh jti, callers can read both back after signing
+func (tm *TokenManager) GenerateJWTWithTTL(claims jwt.MapClaims, ttl time.Duration) (string, error) {
 
-	claims["exp"] = time.Now().Add(tm.ExpiresInSecond).Unix()
+	claims["exp"] = time.Now().Add(ttl).Unix()
+	claims[TokenIDClaim] = uuid.NewString()
 	token := jwt.NewWithClaims(jwt.GetSigningMethod(tm.alg), claims)
+	token.Header["kid"] = tm.kid
 
-	switch tm.alg {
-	case RS256:
-		return token.SignedString(tm.privateKey.(*rsa.PrivateKey))
-	default:
-		return "", errors.New("unsupported algorithm")
-	}
+	return token.SignedString(tm.privateKey)
 }
 
 func (tm *TokenManager) GenerateRefreshToken() (string, error) {
+	return GenerateOpaqueToken()
+}
+
+// random hex token for refresh, reset or verification links
+func GenerateOpaqueToken() (string, error) {
 	bytes := make([]byte, 32)
 	_, err := rand.Read(bytes)
 	if err != nil {
@@ -67,30 +288,20 @@ func (tm *TokenManager) GenerateRefreshToken() (string, error) {
 	return hex.EncodeToString(bytes), nil
 }
 
-// refresh
-func (tm *TokenManager) RefreshJWT(tokenString string) (string, error) {
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		return tm.publicKey, nil
-	})
-	if err != nil || !token.Valid {
-		return "", errors.New("invalid token")
-	}
-
-	claims, ok := token.Claims.(jwt.MapClaims)
-	if !ok {
-		return "", errors.New("cannot extract claims")
-	}
+// keyed hash (HMAC-SHA256) of a token, only the hash is stored so a database leak does not expose usable tokens
+func (tm *TokenManager) HashToken(token string) string {
+	return HashToken(tm.tokenHashSecret, token)
+}
 
-	// remove old expiration
-	delete(claims, "exp")
-	return tm.GenerateJWT(claims)
+func HashToken(secret []byte, token string) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(token))
+	return hex.EncodeToString(mac.Sum(nil))
 }
 
-// validate RSA jwt
+// validate jwt signed with the configured algorithm
 func (tm *TokenManager) ValidateJWT(tokenString string) (jwt.MapClaims, error) {
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		return tm.publicKey, nil
-	})
+	token, err := tm.ParseJWT(tokenString)
 	if err != nil || !token.Valid {
 		return nil, errors.New("invalid token")
 	}
@@ -102,15 +313,32 @@ func (tm *TokenManager) ValidateJWT(tokenString string) (jwt.MapClaims, error) {
 	return claims, nil
 }
 
-func CreateUserClaims(userID, username, email string, roles []string) jwt.MapClaims {
+// claims of a client acting as itself, sub is the client id
+func (tm *TokenManager) CreateClientClaims(clientID string, scopes []string) jwt.MapClaims {
+	now := time.Now().Unix()
+	return jwt.MapClaims{
+		ClientIDClaim: clientID,
+		ScopeClaim:    strings.Join(scopes, " "),
+		"iss":         tm.issuer,
+		"sub":         clientID,
+		"aud":         tm.audience,
+		"iat":         now,
+		"nbf":         now,
+	}
+}
+
+func (tm *TokenManager) CreateUserClaims(userID, username, email string, roles, permissions []string) jwt.MapClaims {
+	now := time.Now().Unix()
 	return jwt.MapClaims{
-		"user_id":  userID,
-		"username": username,
-		"email":    email,
-		"roles":    roles,
-		"iss":      "your-app",
-		"sub":      userID,
-		"aud":      "your-api",
-		"iat":      time.Now().Unix(),
+		"user_id":        userID,
+		"username":       username,
+		"email":          email,
+		"roles":          roles,
+		PermissionsClaim: permissions,
+		"iss":            tm.issuer,
+		"sub":            userID,
+		"aud":            tm.audience,
+		"iat":            now,
+		"nbf":            now,
 	}
 }
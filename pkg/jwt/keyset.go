@@ -0,0 +1,175 @@
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"oapi-to-rest/pkg/helper"
+)
+
+// public key in JSON Web Key format (RFC 7517)
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+
+	// RSA
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+
+	// EC and OKP
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+func loadPrivateKey(alg, base64PEM string) (any, error) {
+	switch alg {
+	case RS256:
+		return helper.LoadRSAPrivateKey(base64PEM)
+	case ES256:
+		return helper.LoadECDSAPrivateKey(base64PEM)
+	case EdDSA:
+		return helper.LoadEd25519PrivateKey(base64PEM)
+	default:
+		return nil, fmt.Errorf("no private key for algorithm %s", alg)
+	}
+}
+
+func loadPublicKey(alg, base64PEM string) (any, error) {
+	switch alg {
+	case RS256:
+		return helper.LoadRSAPublicKey(base64PEM)
+	case ES256:
+		return helper.LoadECDSAPublicKey(base64PEM)
+	case EdDSA:
+		return helper.LoadEd25519PublicKey(base64PEM)
+	default:
+		return nil, fmt.Errorf("no public key for algorithm %s", alg)
+	}
+}
+
+func (tm *TokenManager) addVerificationKey(kid string, key any) error {
+	if _, exists := tm.publicKeys[kid]; exists {
+		return fmt.Errorf("duplicate signing key id %q", kid)
+	}
+	tm.publicKeys[kid] = key
+	tm.kids = append(tm.kids, kid)
+	return nil
+}
+
+// public verification keys, active key first. symmetric HS256 keys are never published
+func (tm *TokenManager) JWKS() ([]JWK, error) {
+	keys := []JWK{}
+	if tm.alg == HS256 {
+		return keys, nil
+	}
+
+	for _, kid := range tm.kids {
+		jwk, err := NewJWK(tm.publicKeys[kid])
+		if err != nil {
+			return nil, err
+		}
+		jwk.Kid, jwk.Use, jwk.Alg = kid, "sig", tm.alg
+		keys = append(keys, jwk)
+	}
+	return keys, nil
+}
+
+// JWK of a public key, without kid, use and alg
+func NewJWK(key any) (JWK, error) {
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		return JWK{
+			Kty: "RSA",
+			N:   b64(k.N.Bytes()),
+			E:   b64(big.NewInt(int64(k.E)).Bytes()),
+		}, nil
+	case *ecdsa.PublicKey:
+		size := (k.Curve.Params().BitSize + 7) / 8
+		return JWK{
+			Kty: "EC",
+			Crv: k.Curve.Params().Name,
+			X:   b64(k.X.FillBytes(make([]byte, size))),
+			Y:   b64(k.Y.FillBytes(make([]byte, size))),
+		}, nil
+	case ed25519.PublicKey:
+		return JWK{
+			Kty: "OKP",
+			Crv: "Ed25519",
+			X:   b64(k),
+		}, nil
+	default:
+		return JWK{}, fmt.Errorf("unsupported public key type %T", key)
+	}
+}
+
+// public key of a JWK, the inverse of NewJWK
+func (k JWK) PublicKey() (any, error) {
+	switch k.Kty {
+	case "RSA":
+		n, errN := base64.RawURLEncoding.DecodeString(k.N)
+		e, errE := base64.RawURLEncoding.DecodeString(k.E)
+		if errN != nil || errE != nil || len(n) == 0 || len(e) == 0 {
+			return nil, fmt.Errorf("invalid RSA key %q", k.Kid)
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "EC":
+		if k.Crv != elliptic.P256().Params().Name {
+			return nil, fmt.Errorf("unsupported curve %q of key %q", k.Crv, k.Kid)
+		}
+		x, errX := base64.RawURLEncoding.DecodeString(k.X)
+		y, errY := base64.RawURLEncoding.DecodeString(k.Y)
+		if errX != nil || errY != nil {
+			return nil, fmt.Errorf("invalid EC key %q", k.Kid)
+		}
+		key := &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if !key.Curve.IsOnCurve(key.X, key.Y) {
+			return nil, fmt.Errorf("invalid EC key %q", k.Kid)
+		}
+		return key, nil
+	case "OKP":
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil || k.Crv != "Ed25519" || len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid OKP key %q", k.Kid)
+		}
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q of key %q", k.Kty, k.Kid)
+	}
+}
+
+// RFC 7638 thumbprint, the hash of the required members in lexicographic order
+func Thumbprint(key any) (string, error) {
+	var canonical string
+	switch k := key.(type) {
+	case []byte:
+		canonical = fmt.Sprintf(`{"k":"%s","kty":"oct"}`, b64(k))
+	default:
+		jwk, err := NewJWK(key)
+		if err != nil {
+			return "", err
+		}
+		switch jwk.Kty {
+		case "RSA":
+			canonical = fmt.Sprintf(`{"e":"%s","kty":"RSA","n":"%s"}`, jwk.E, jwk.N)
+		case "EC":
+			canonical = fmt.Sprintf(`{"crv":"%s","kty":"EC","x":"%s","y":"%s"}`, jwk.Crv, jwk.X, jwk.Y)
+		case "OKP":
+			canonical = fmt.Sprintf(`{"crv":"%s","kty":"OKP","x":"%s"}`, jwk.Crv, jwk.X)
+		}
+	}
+
+	sum := sha256.Sum256([]byte(canonical))
+	return b64(sum[:]), nil
+}
+
+func b64(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
@@ -0,0 +1,81 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// writes messages to a writer (e.g. stdout), for local development only
+type WriterSender struct {
+	w    io.Writer
+	from string
+	mu   sync.Mutex
+}
+
+func NewWriterSender(w io.Writer, from string) *WriterSender {
+	return &WriterSender{w: w, from: from}
+}
+
+func (s *WriterSender) Send(ctx context.Context, msg Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err := io.WriteString(s.w, format(withDefaultFrom(msg, s.from))+"\n")
+	return err
+}
+
+// writes each message as a file in outbox directory, useful for local development and tests
+type FileSender struct {
+	dir  string
+	from string
+}
+
+func NewFileSender(dir, from string) (*FileSender, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("outbox directory is required for file mail driver")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create outbox directory: %w", err)
+	}
+	return &FileSender{dir: dir, from: from}, nil
+}
+
+func (s *FileSender) Send(ctx context.Context, msg Message) error {
+	msg = withDefaultFrom(msg, s.from)
+
+	name := fmt.Sprintf("%d_%s.eml", time.Now().UnixNano(), sanitizeFilename(msg.To))
+	return os.WriteFile(filepath.Join(s.dir, name), []byte(format(msg)), 0o600)
+}
+
+func withDefaultFrom(msg Message, from string) Message {
+	if msg.From == "" {
+		msg.From = from
+	}
+	return msg
+}
+
+func format(msg Message) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", msg.From)
+	fmt.Fprintf(&b, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", msg.Subject)
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().UTC().Format(time.RFC1123Z))
+	b.WriteString("\r\n")
+	b.WriteString(msg.Body)
+	return b.String()
+}
+
+func sanitizeFilename(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || r == os.PathSeparator {
+			return '_'
+		}
+		return r
+	}, s)
+}
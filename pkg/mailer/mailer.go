@@ -0,0 +1,42 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"os"
+)
+
+const (
+	DriverStdout = "stdout"
+	DriverFile   = "file"
+)
+
+type MailConfig struct {
+	Driver    string
+	OutboxDir string
+	From      string
+}
+
+type Message struct {
+	From    string
+	To      string
+	Subject string
+	Body    string
+}
+
+// mail delivery abstraction, implement this for smtp or third party providers
+type Sender interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// create sender based on configured driver, default to stdout for local development
+func New(cfg MailConfig) (Sender, error) {
+	switch cfg.Driver {
+	case DriverFile:
+		return NewFileSender(cfg.OutboxDir, cfg.From)
+	case DriverStdout, "":
+		return NewWriterSender(os.Stdout, cfg.From), nil
+	default:
+		return nil, fmt.Errorf("unsupported mail driver: %s", cfg.Driver)
+	}
+}
@@ -0,0 +1,132 @@
+package middleware
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	appjwt "oapi-to-rest/pkg/jwt"
+	"oapi-to-rest/pkg/rbac"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// last_used_at is written at most once per interval to avoid a write on every request
+const apiKeyLastUsedInterval = time.Minute
+
+var (
+	errAPIKeyInvalid = errors.New("api key is invalid or revoked")
+	errAPIKeyExpired = errors.New("api key has expired")
+)
+
+// accept X-API-Key on routes using AuthorizationBearerOrAPIKey, keys are stored as HMAC with the token hash secret
+func (j *JWTMiddleware) EnableAPIKeys(tokenHashSecret string) {
+	if tokenHashSecret == "" {
+		return
+	}
+	j.apiKeyHashSecret = []byte(tokenHashSecret)
+}
+
+// authorize with bearer token or api key, both resolve to the same claims in gin context,
+// bearer token is used when both are sent
+func (j *JWTMiddleware) AuthorizationBearerOrAPIKey() func(c *gin.Context) {
+	bearer := j.AuthorizationBearerJWT()
+	return func(c *gin.Context) {
+
+		key := c.GetHeader(APIKeyHeader)
+		if key == "" || c.GetHeader("Authorization") != "" || j.apiKeyHashSecret == nil || j.sqlite == nil || j.tokens == nil {
+			bearer(c)
+			return
+		}
+
+		claims, err := j.validateAPIKey(c, key)
+		if err != nil {
+			if errors.Is(err, errAPIKeyInvalid) || errors.Is(err, errAPIKeyExpired) {
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": fmt.Sprintf("Invalid api key: %v", err)})
+				return
+			}
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "failed to validate api key"})
+			return
+		}
+
+		customClaims, err := appjwt.ParseCustomClaims(claims)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "failed to validate api key"})
+			return
+		}
+		principal := newPrincipal(customClaims, AuthMethodAPIKey)
+
+		// operation scopes declared in spec must all be granted to the key
+		operationScopes, _ := c.Get(APIKeyAuthScopes)
+		required, _ := operationScopes.([]string)
+		if scope, ok := missingScope(principal.Scopes, required); ok {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": fmt.Sprintf("api key is missing scope %q", scope)})
+			return
+		}
+
+		setPrincipal(c, principal)
+	}
+}
+
+func (j *JWTMiddleware) validateAPIKey(ctx context.Context, key string) (jwt.MapClaims, error) {
+
+	var (
+		keyID      string
+		userID     int64
+		email      string
+		scopes     string
+		lastUsedAt sql.NullTime
+		expiresAt  sql.NullTime
+	)
+	err := j.sqlite.DB.QueryRowContext(ctx, `
+		SELECT k.id, k.user_id, u.email, k.scopes, k.last_used_at, k.expires_at
+		FROM api_keys k
+		JOIN users u ON u.id = k.user_id
+		WHERE k.key_hash = $1 AND k.revoked_at IS NULL;
+	`, appjwt.HashToken(j.apiKeyHashSecret, key)).Scan(&keyID, &userID, &email, &scopes, &lastUsedAt, &expiresAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errAPIKeyInvalid
+		}
+		return nil, err
+	}
+
+	now := time.Now()
+	if expiresAt.Valid && now.After(expiresAt.Time) {
+		return nil, errAPIKeyExpired
+	}
+
+	roles, err := rbac.UserRoles(ctx, j.sqlite, userID)
+	if err != nil {
+		return nil, err
+	}
+	permissions, err := rbac.UserPermissions(ctx, j.sqlite, userID)
+	if err != nil {
+		return nil, err
+	}
+	// a key only carries the owner's permissions it was scoped to
+	keyScopes := strings.Fields(scopes)
+	permissions = slices.DeleteFunc(permissions, func(permission string) bool {
+		return !slices.Contains(keyScopes, permission)
+	})
+
+	if !lastUsedAt.Valid || now.Sub(lastUsedAt.Time) > apiKeyLastUsedInterval {
+		if _, err := j.sqlite.DB.ExecContext(ctx, `
+			UPDATE api_keys SET last_used_at = $1 WHERE id = $2;
+		`, now, keyID); err != nil {
+			return nil, err
+		}
+	}
+
+	claims := j.tokens.CreateUserClaims(strconv.FormatInt(userID, 10), "", email, roles, permissions)
+	claims[appjwt.APIKeyIDClaim] = keyID
+	claims[appjwt.ScopeClaim] = scopes
+
+	return claims, nil
+}
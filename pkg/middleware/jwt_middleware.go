@@ -1,24 +1,61 @@
 package middleware
 
 import (
-	"crypto/rsa"
+	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"net/http"
-	"oapi-to-rest/pkg/helper"
+	"oapi-to-rest/pkg/db"
+	"oapi-to-rest/pkg/errlib"
+	appjwt "oapi-to-rest/pkg/jwt"
+	"oapi-to-rest/pkg/revocation"
+	"slices"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
 )
 
+const (
+	// gin context key set by oapi-codegen wrappers on operations secured with bearerAuth
+	BearerAuthScopes = "bearerAuth.Scopes"
+
+	// gin context key set by oapi-codegen wrappers on operations secured with apiKeyAuth
+	APIKeyAuthScopes = "apiKeyAuth.Scopes"
+
+	// header of personal api keys
+	APIKeyHeader = "X-API-Key"
+)
+
+var (
+	errSessionNotBound = errors.New("token is not bound to a session")
+	errSessionRevoked  = errors.New("session has been revoked")
+)
+
 type JWTMiddleware struct {
-	publicKey *rsa.PublicKey
+	tokens *appjwt.TokenManager
+	sqlite *db.SQLite
+
+	// routes reachable with a token limited to mfa enrollment
+	mfaEnrollmentPaths map[string]bool
+
+	// routes denied to tokens of an admin impersonating a user
+	impersonationBlockedPaths map[string]bool
+
+	// secret of stored api key hashes, api keys are rejected when nil
+	apiKeyHashSecret []byte
+
+	// revoked token ids, revocation check is skipped when nil
+	revocations revocation.Store
 }
 
-func NewAuthenticationMiddleware(publicKeyBase64 string) *JWTMiddleware {
-	pubKey, _ := helper.LoadRSAPublicKey(publicKeyBase64)
+// tokens are verified with the algorithm and key of the token manager,
+// sqlite is used to check the session of the token, session check is skipped when nil
+func NewAuthenticationMiddleware(tokens *appjwt.TokenManager, sqlite *db.SQLite) *JWTMiddleware {
 	return &JWTMiddleware{
-		publicKey: pubKey,
+		tokens: tokens,
+		sqlite: sqlite,
 	}
 }
 
@@ -42,15 +79,15 @@ func (j *JWTMiddleware) AuthorizationBearerJWT() func(c *gin.Context) {
 			return
 		}
 
-		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-			if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
-				return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-			}
-			return j.publicKey, nil
-		})
+		if j.tokens == nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "token verification is not configured"})
+			return
+		}
 
+		token, err := j.tokens.ParseJWT(tokenString)
 		if err != nil {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": fmt.Sprintf("Invalid token: %v", err)})
+			appErr := errlib.NewAppError(tokenErrorCode(err))
+			c.AbortWithStatusJSON(appErr.Status, gin.H{"error": fmt.Sprintf("Invalid token: %v", err), "code": appErr.Code})
 			return
 		}
 
@@ -59,8 +96,157 @@ func (j *JWTMiddleware) AuthorizationBearerJWT() func(c *gin.Context) {
 			return
 		}
 
-		if claims, ok := token.Claims.(jwt.MapClaims); ok {
-			c.Set("claims", claims)
+		claims, ok := token.Claims.(jwt.MapClaims)
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid token claims"})
+			return
+		}
+
+		// reject individually revoked token
+		if j.revocations != nil {
+			jti, _ := claims[appjwt.TokenIDClaim].(string)
+			revoked, err := j.revocations.IsRevoked(c, jti)
+			if err != nil {
+				c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "failed to check token revocation"})
+				return
+			}
+			if revoked {
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid token: token has been revoked", "code": errlib.ErrCodeTokenRevoked})
+				return
+			}
+		}
+
+		customClaims, err := appjwt.ParseCustomClaims(claims)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid token claims"})
+			return
+		}
+		principal := newPrincipal(customClaims, AuthMethodBearer)
+
+		// client_credentials tokens have no session, operation scopes declared in spec must be granted instead
+		if principal.Type == PrincipalClient {
+			operationScopes, _ := c.Get(BearerAuthScopes)
+			required, _ := operationScopes.([]string)
+			if scope, ok := missingScope(principal.Scopes, required); ok {
+				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": fmt.Sprintf("client token is missing scope %q", scope)})
+				return
+			}
+		}
+
+		// reject token of revoked session
+		if j.sqlite != nil && principal.Type == PrincipalUser {
+			if err := j.validateSession(c, principal.SessionID); err != nil {
+				if errors.Is(err, errSessionNotBound) || errors.Is(err, errSessionRevoked) {
+					c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": fmt.Sprintf("Invalid token: %v", err)})
+					return
+				}
+				c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "failed to validate session"})
+				return
+			}
+		}
+
+		// token limited to mfa enrollment
+		if customClaims.MFAEnrollment && !j.mfaEnrollmentPaths[c.FullPath()] {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "MFA enrollment required"})
+			return
+		}
+
+		// sensitive operation under impersonation
+		if principal.ActorID != "" && j.impersonationBlockedPaths[c.FullPath()] {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "operation not allowed while impersonating"})
+			return
+		}
+
+		setPrincipal(c, principal)
+	}
+}
+
+// allow routes (gin full path) for tokens limited to mfa enrollment
+func (j *JWTMiddleware) AllowDuringMFAEnrollment(paths ...string) {
+	if j.mfaEnrollmentPaths == nil {
+		j.mfaEnrollmentPaths = map[string]bool{}
+	}
+	for _, path := range paths {
+		j.mfaEnrollmentPaths[path] = true
+	}
+}
+
+// reject tokens whose jti is in the store
+func (j *JWTMiddleware) EnableRevocationList(store revocation.Store) {
+	j.revocations = store
+}
+
+// deny routes (gin full path) for tokens carrying an actor claim
+func (j *JWTMiddleware) BlockDuringImpersonation(paths ...string) {
+	if j.impersonationBlockedPaths == nil {
+		j.impersonationBlockedPaths = map[string]bool{}
+	}
+	for _, path := range paths {
+		j.impersonationBlockedPaths[path] = true
+	}
+}
+
+// same as AuthorizationBearerJWT but only applied to operations that declare bearerAuth security in spec,
+// used when only part of the operations in a generated package are protected
+func (j *JWTMiddleware) SecuredOperationBearerJWT() func(c *gin.Context) {
+	authorize := j.AuthorizationBearerJWT()
+	return func(c *gin.Context) {
+		if _, secured := c.Get(BearerAuthScopes); !secured {
+			return
+		}
+		authorize(c)
+	}
+}
+
+func (j *JWTMiddleware) validateSession(ctx context.Context, sessionID string) error {
+
+	if sessionID == "" {
+		return errSessionNotBound
+	}
+
+	var isValid int
+	err := j.sqlite.DB.QueryRowContext(ctx, `
+		SELECT is_valid FROM user_sessions
+		WHERE id = $1;
+	`, sessionID).Scan(&isValid)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errSessionRevoked
+		}
+		return err
+	}
+
+	if isValid != 1 {
+		return errSessionRevoked
+	}
+
+	return nil
+}
+
+// error code telling clients why a token was rejected, e.g. expired tokens can be refreshed
+func tokenErrorCode(err error) string {
+	switch {
+	case errors.Is(err, jwt.ErrTokenExpired):
+		return errlib.ErrCodeTokenExpired
+	case errors.Is(err, jwt.ErrTokenNotValidYet), errors.Is(err, jwt.ErrTokenUsedBeforeIssued):
+		return errlib.ErrCodeTokenNotYetValid
+	case errors.Is(err, jwt.ErrTokenInvalidAudience):
+		return errlib.ErrCodeInvalidTokenAudience
+	case errors.Is(err, jwt.ErrTokenInvalidIssuer):
+		return errlib.ErrCodeInvalidTokenIssuer
+	case errors.Is(err, jwt.ErrTokenSignatureInvalid), errors.Is(err, jwt.ErrTokenUnverifiable):
+		return errlib.ErrCodeInvalidTokenSignature
+	default:
+		return errlib.ErrCodeInvalidToken
+	}
+}
+
+// first scope of required that is not granted
+func missingScope(granted []string, required []string) (string, bool) {
+	for _, scope := range required {
+		if !slices.Contains(granted, scope) {
+			return scope, true
 		}
 	}
+	return "", false
 }
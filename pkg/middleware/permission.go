@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"oapi-to-rest/pkg/errlib"
+
+	"github.com/gin-gonic/gin"
+	strictgin "github.com/oapi-codegen/runtime/strictmiddleware/gin"
+)
+
+// deny principals missing any of the permissions, must follow the bearer or api key middleware.
+// applies to every operation of a package when used in GinServerOptions.Middlewares
+func RequirePermission(permissions ...string) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		if err := checkPermissions(c, permissions); err != nil {
+			c.AbortWithStatusJSON(err.Status, gin.H{"error": err.Message, "code": err.Code})
+		}
+	}
+}
+
+// strict middleware denying operations (by operation id) to principals missing any of their permissions,
+// operations not in the map are not checked
+func RequireOperationPermissions(operations map[string][]string) strictgin.StrictGinMiddlewareFunc {
+	return func(f strictgin.StrictGinHandlerFunc, operationID string) strictgin.StrictGinHandlerFunc {
+		permissions, ok := operations[operationID]
+		if !ok {
+			return f
+		}
+		return func(c *gin.Context, request interface{}) (interface{}, error) {
+			if err := checkPermissions(c, permissions); err != nil {
+				return nil, err
+			}
+			return f(c, request)
+		}
+	}
+}
+
+func checkPermissions(c *gin.Context, permissions []string) *errlib.AppError {
+	p, ok := PrincipalFromContext(c)
+	if !ok {
+		return errlib.NewAppErrorWithLog(errors.New("missing principal"), errlib.ErrCodeUnauthorized)
+	}
+	for _, permission := range permissions {
+		if !p.HasPermission(permission) {
+			return errlib.NewAppErrorWithLog(fmt.Errorf("%s %s is missing permission %q", p.Type, p.ID(), permission), errlib.ErrCodeForbidden)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+
+	appjwt "oapi-to-rest/pkg/jwt"
+
+	"github.com/gin-gonic/gin"
+)
+
+// gin context key of the authenticated principal
+const PrincipalKey = "principal"
+
+type PrincipalType string
+
+const (
+	PrincipalUser   PrincipalType = "user"
+	PrincipalClient PrincipalType = "client"
+)
+
+type AuthMethod string
+
+const (
+	AuthMethodBearer AuthMethod = "bearer"
+	AuthMethodAPIKey AuthMethod = "api_key"
+)
+
+// caller of the request, set by the bearer and api key middlewares
+type Principal struct {
+	Type       PrincipalType
+	AuthMethod AuthMethod
+
+	// empty for client principals
+	UserID      string
+	Email       string
+	Roles       []string
+	Permissions []string
+
+	// empty for user principals
+	ClientID string
+
+	// granted to api keys and clients, user tokens are not scoped
+	Scopes []string
+
+	// empty unless authenticated by a session token
+	SessionID string
+
+	// admin impersonating UserID, empty otherwise
+	ActorID string
+}
+
+// user id or client id
+func (p *Principal) ID() string {
+	if p.Type == PrincipalClient {
+		return p.ClientID
+	}
+	return p.UserID
+}
+
+func (p *Principal) HasPermission(permission string) bool {
+	return slices.Contains(p.Permissions, permission)
+}
+
+// context.Context key, unexported so only this package can set the principal
+type principalContextKey struct{}
+
+func newPrincipal(claims *appjwt.CustomClaims, method AuthMethod) *Principal {
+	p := &Principal{
+		Type:        PrincipalUser,
+		AuthMethod:  method,
+		UserID:      claims.UserID,
+		Email:       claims.Email,
+		Roles:       claims.Roles,
+		Permissions: claims.Permissions,
+		Scopes:      strings.Fields(claims.Scope),
+		SessionID:   claims.SessionID,
+	}
+	if claims.ClientID != "" && claims.UserID == "" {
+		p.Type = PrincipalClient
+		p.ClientID = claims.ClientID
+	}
+	if claims.Actor != nil {
+		p.ActorID = claims.Actor.Subject
+	}
+	return p
+}
+
+// store principal in gin context and in the request context passed on to handlers
+func setPrincipal(c *gin.Context, p *Principal) {
+	c.Set(PrincipalKey, p)
+	c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), principalContextKey{}, p))
+}
+
+// principal of the request, ctx is either the *gin.Context given to strict handlers or a request context
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	if c, ok := ctx.(*gin.Context); ok {
+		if value, exists := c.Get(PrincipalKey); exists {
+			p, ok := value.(*Principal)
+			return p, ok
+		}
+		if c.Request == nil {
+			return nil, false
+		}
+		ctx = c.Request.Context()
+	}
+	p, ok := ctx.Value(principalContextKey{}).(*Principal)
+	return p, ok
+}
+
+// deny client tokens on operations acting on behalf of a user, must follow the bearer middleware
+func RequireUserPrincipal() func(c *gin.Context) {
+	return func(c *gin.Context) {
+		if p, ok := PrincipalFromContext(c); ok && p.Type != PrincipalUser {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "operation requires a user token"})
+		}
+	}
+}
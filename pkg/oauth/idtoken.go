@@ -0,0 +1,152 @@
+package oauth
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	appjwt "oapi-to-rest/pkg/jwt"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	// provider keys are fetched again after this long, or on an unknown kid
+	jwksCacheTTL = time.Hour
+
+	// unknown kids do not trigger a fetch more often than this
+	jwksMinRefresh = time.Minute
+
+	// clock skew tolerated on exp and iat of id tokens
+	idTokenLeeway = time.Minute
+)
+
+// identity claims of a verified id_token
+type IDToken struct {
+	Subject       string
+	Email         string
+	EmailVerified *bool
+}
+
+// cached verification keys of the provider by kid
+type keySet struct {
+	mu        sync.Mutex
+	keys      map[string]any
+	fetchedAt time.Time
+}
+
+// OIDC providers issue an id_token that is verified at the callback
+func (p *Provider) IsOIDC() bool {
+	return p.cfg.JWKSURL != ""
+}
+
+// verify signature (provider JWKS), iss, aud, exp and nonce of an id_token
+func (p *Provider) VerifyIDToken(ctx context.Context, rawIDToken, nonce string) (*IDToken, error) {
+	if p.cfg.Issuer == "" {
+		return nil, errors.New("id token: issuer of provider is not configured")
+	}
+	if rawIDToken == "" {
+		return nil, errors.New("id token: missing from token response")
+	}
+
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(rawIDToken, claims, func(token *jwt.Token) (any, error) {
+		kid, _ := token.Header["kid"].(string)
+		return p.verificationKey(ctx, kid)
+	},
+		jwt.WithValidMethods([]string{"RS256", "ES256", "EdDSA"}),
+		jwt.WithIssuer(p.cfg.Issuer),
+		jwt.WithAudience(p.cfg.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(idTokenLeeway),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("id token: %w", err)
+	}
+
+	// nonce ties the token to the login started with this state
+	tokenNonce, _ := claims["nonce"].(string)
+	if nonce == "" || subtle.ConstantTimeCompare([]byte(tokenNonce), []byte(nonce)) != 1 {
+		return nil, errors.New("id token: nonce mismatch")
+	}
+
+	idToken := &IDToken{
+		Subject: stringField(claims, "sub"),
+		Email:   stringField(claims, "email"),
+	}
+	if verified, ok := claims["email_verified"]; ok {
+		emailVerified := verified == true || verified == "true"
+		idToken.EmailVerified = &emailVerified
+	}
+
+	if idToken.Subject == "" {
+		return nil, errors.New("id token: missing subject")
+	}
+	return idToken, nil
+}
+
+// key named by kid, keys are fetched again when stale or the kid is unknown (rotation)
+func (p *Provider) verificationKey(ctx context.Context, kid string) (any, error) {
+	p.keys.mu.Lock()
+	defer p.keys.mu.Unlock()
+
+	key, known := p.lookupKey(kid)
+	age := time.Since(p.keys.fetchedAt)
+	if age > jwksCacheTTL || (!known && age > jwksMinRefresh) {
+		if err := p.fetchKeys(ctx); err != nil {
+			return nil, err
+		}
+		key, known = p.lookupKey(kid)
+	}
+
+	if !known {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+	return key, nil
+}
+
+// tokens without kid are accepted when the provider publishes a single key
+func (p *Provider) lookupKey(kid string) (any, bool) {
+	if kid == "" && len(p.keys.keys) == 1 {
+		for _, key := range p.keys.keys {
+			return key, true
+		}
+	}
+	key, ok := p.keys.keys[kid]
+	return key, ok
+}
+
+func (p *Provider) fetchKeys(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.cfg.JWKSURL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	var set struct {
+		Keys []appjwt.JWK `json:"keys"`
+	}
+	if err := p.do(req, &set); err != nil {
+		return fmt.Errorf("jwks: %w", err)
+	}
+
+	// keys of unsupported types or for encryption are skipped
+	keys := map[string]any{}
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.PublicKey()
+		if err != nil {
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+
+	p.keys.keys = keys
+	p.keys.fetchedAt = time.Now()
+	return nil
+}
@@ -0,0 +1,230 @@
+package oauth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// generic OAuth2 authorization code provider, identity is read from the userinfo endpoint.
+// OIDC providers (JWKSURL set) also have their id_token verified, subject and email_verified come from it
+type ProviderConfig struct {
+	Name         string
+	ClientID     string
+	ClientSecret string
+	AuthURL      string
+	TokenURL     string
+	UserInfoURL  string
+	RedirectURL  string
+	Scopes       []string
+
+	// userinfo fields, "sub" and "email" for OIDC providers
+	SubjectField string
+	EmailField   string
+
+	// treat provider email as verified when userinfo has no email_verified field (e.g. github)
+	TrustEmail bool
+
+	// OIDC only, expected iss of id tokens and the keys they are signed with
+	Issuer  string
+	JWKSURL string
+}
+
+type Token struct {
+	AccessToken      string `json:"access_token"`
+	TokenType        string `json:"token_type"`
+	IDToken          string `json:"id_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+type UserInfo struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	GivenName     string
+	FamilyName    string
+	Name          string
+}
+
+type Provider struct {
+	cfg        ProviderConfig
+	httpClient *http.Client
+	keys       keySet
+}
+
+func NewProvider(cfg ProviderConfig) *Provider {
+	if cfg.SubjectField == "" {
+		cfg.SubjectField = "sub"
+	}
+	if cfg.EmailField == "" {
+		cfg.EmailField = "email"
+	}
+	return &Provider{
+		cfg:        cfg,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (p *Provider) Name() string {
+	return p.cfg.Name
+}
+
+// authorization endpoint url with state and PKCE S256 challenge, nonce is only sent to OIDC providers
+func (p *Provider) AuthCodeURL(state, codeChallenge, nonce string) string {
+	query := url.Values{}
+	query.Set("response_type", "code")
+	query.Set("client_id", p.cfg.ClientID)
+	query.Set("redirect_uri", p.cfg.RedirectURL)
+	query.Set("scope", strings.Join(p.cfg.Scopes, " "))
+	query.Set("state", state)
+	query.Set("code_challenge", codeChallenge)
+	query.Set("code_challenge_method", "S256")
+	if p.IsOIDC() {
+		query.Set("nonce", nonce)
+	}
+
+	sep := "?"
+	if strings.Contains(p.cfg.AuthURL, "?") {
+		sep = "&"
+	}
+	return p.cfg.AuthURL + sep + query.Encode()
+}
+
+// exchange authorization code for tokens
+func (p *Provider) Exchange(ctx context.Context, code, codeVerifier string) (*Token, error) {
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.cfg.RedirectURL)
+	form.Set("client_id", p.cfg.ClientID)
+	form.Set("client_secret", p.cfg.ClientSecret)
+	form.Set("code_verifier", codeVerifier)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.cfg.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	var token Token
+	if err := p.do(req, &token); err != nil {
+		return nil, fmt.Errorf("token exchange: %w", err)
+	}
+	if token.Error != "" {
+		return nil, fmt.Errorf("token exchange: %s %s", token.Error, token.ErrorDescription)
+	}
+	if token.AccessToken == "" {
+		return nil, errors.New("token exchange: missing access token")
+	}
+	return &token, nil
+}
+
+// user identity from userinfo endpoint
+func (p *Provider) UserInfo(ctx context.Context, accessToken string) (*UserInfo, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.cfg.UserInfoURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Accept", "application/json")
+
+	var fields map[string]any
+	if err := p.do(req, &fields); err != nil {
+		return nil, fmt.Errorf("userinfo: %w", err)
+	}
+
+	info := &UserInfo{
+		Subject:    stringField(fields, p.cfg.SubjectField),
+		Email:      stringField(fields, p.cfg.EmailField),
+		GivenName:  stringField(fields, "given_name"),
+		FamilyName: stringField(fields, "family_name"),
+		Name:       stringField(fields, "name"),
+	}
+	if verified, ok := fields["email_verified"]; ok {
+		info.EmailVerified = verified == true || verified == "true"
+	} else {
+		info.EmailVerified = p.cfg.TrustEmail
+	}
+
+	if info.Subject == "" {
+		return nil, errors.New("userinfo: missing subject")
+	}
+	return info, nil
+}
+
+// identity of the logged in user, from userinfo checked against the id_token for OIDC providers
+func (p *Provider) Identity(ctx context.Context, token *Token, nonce string) (*UserInfo, error) {
+	info, err := p.UserInfo(ctx, token.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+	if !p.IsOIDC() {
+		return info, nil
+	}
+
+	idToken, err := p.VerifyIDToken(ctx, token.IDToken, nonce)
+	if err != nil {
+		return nil, err
+	}
+	if idToken.Subject != info.Subject {
+		return nil, errors.New("userinfo: subject does not match id token")
+	}
+	if idToken.Email != "" {
+		info.Email = idToken.Email
+	}
+	if idToken.EmailVerified != nil {
+		info.EmailVerified = *idToken.EmailVerified
+	}
+	return info, nil
+}
+
+func (p *Provider) do(req *http.Request, out any) error {
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, body)
+	}
+	return json.Unmarshal(body, out)
+}
+
+// string value of field, numeric ids are formatted without exponent
+func stringField(fields map[string]any, name string) string {
+	switch v := fields[name].(type) {
+	case string:
+		return v
+	case float64:
+		return fmt.Sprintf("%.0f", v)
+	default:
+		return ""
+	}
+}
+
+// PKCE verifier and its S256 challenge
+func GeneratePKCE() (string, string, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", "", err
+	}
+	verifier := base64.RawURLEncoding.EncodeToString(raw)
+	sum := sha256.Sum256([]byte(verifier))
+	return verifier, base64.RawURLEncoding.EncodeToString(sum[:]), nil
+}
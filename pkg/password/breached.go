@@ -0,0 +1,52 @@
+package password
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+type BreachedChecker interface {
+	IsBreached(password string) (bool, error)
+}
+
+// offline k-anonymity style lookup: SHA-1 of the password is split into a 5 character prefix
+// naming the file and a 35 character suffix listed in it as "SUFFIX:COUNT" lines,
+// the same layout as the pwned passwords range api
+type PrefixFileChecker struct {
+	dir string
+}
+
+func NewPrefixFileChecker(dir string) *PrefixFileChecker {
+	return &PrefixFileChecker{dir: dir}
+}
+
+func (c *PrefixFileChecker) IsBreached(password string) (bool, error) {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	prefix, suffix := hash[:5], hash[5:]
+
+	file, err := os.Open(filepath.Join(c.dir, prefix+".txt"))
+	if err != nil {
+		// no file means no breached password with this prefix
+		if errors.Is(err, fs.ErrNotExist) {
+			return false, nil
+		}
+		return false, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		if strings.EqualFold(line, suffix) {
+			return true, nil
+		}
+	}
+	return false, scanner.Err()
+}
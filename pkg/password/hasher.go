@@ -0,0 +1,81 @@
+package password
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	AlgorithmArgon2id = "argon2id"
+	AlgorithmBcrypt   = "bcrypt"
+)
+
+var ErrUnknownHashFormat = errors.New("unknown password hash format")
+
+type HasherConfig struct {
+	// algorithm of new hashes, argon2id or bcrypt
+	Algorithm string
+
+	BcryptCost int
+
+	// argon2id memory in KiB
+	Argon2Memory      uint32
+	Argon2Iterations  uint32
+	Argon2Parallelism uint8
+}
+
+// password hashing algorithm with self-describing encoded hashes
+type Algorithm interface {
+	Hash(password string) (string, error)
+
+	// reports whether encoded hash was produced by this algorithm
+	Identify(encoded string) bool
+
+	// false without error on mismatch
+	Verify(password, encoded string) (bool, error)
+
+	// hash parameters differ from the configured ones
+	NeedsRehash(encoded string) bool
+}
+
+// hashes with the configured algorithm, verifies hashes of any supported algorithm
+type Hasher struct {
+	current    Algorithm
+	algorithms []Algorithm
+}
+
+func NewHasher(cfg HasherConfig) (*Hasher, error) {
+	argon := NewArgon2id(cfg.Argon2Memory, cfg.Argon2Iterations, cfg.Argon2Parallelism)
+	bcrypt := NewBcrypt(cfg.BcryptCost)
+
+	hasher := &Hasher{algorithms: []Algorithm{argon, bcrypt}}
+	switch cfg.Algorithm {
+	case AlgorithmArgon2id, "":
+		hasher.current = argon
+	case AlgorithmBcrypt:
+		hasher.current = bcrypt
+	default:
+		return nil, fmt.Errorf("unsupported password hash algorithm %q", cfg.Algorithm)
+	}
+	return hasher, nil
+}
+
+func (h *Hasher) Hash(password string) (string, error) {
+	return h.current.Hash(password)
+}
+
+// verify password, rehash reports that the hash should be replaced by one from Hash
+func (h *Hasher) Verify(password, encoded string) (ok bool, rehash bool, err error) {
+	for _, algorithm := range h.algorithms {
+		if !algorithm.Identify(encoded) {
+			continue
+		}
+
+		ok, err := algorithm.Verify(password, encoded)
+		if err != nil || !ok {
+			return false, false, err
+		}
+		return true, algorithm != h.current || algorithm.NeedsRehash(encoded), nil
+	}
+	return false, false, ErrUnknownHashFormat
+}
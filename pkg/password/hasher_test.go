@@ -0,0 +1,175 @@
+package password
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// cheap parameters, hashing cost is not under test
+func testHasher(t *testing.T, algorithm string) *Hasher {
+	t.Helper()
+	hasher, err := NewHasher(HasherConfig{
+		Algorithm:         algorithm,
+		BcryptCost:        bcrypt.MinCost,
+		Argon2Memory:      8 * 1024,
+		Argon2Iterations:  1,
+		Argon2Parallelism: 1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return hasher
+}
+
+func TestArgon2idEncodeDecode(t *testing.T) {
+	argon := NewArgon2id(8*1024, 2, 1)
+
+	encoded, err := argon.Hash("correct horse battery staple")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(encoded, "$argon2id$v=19$m=8192,t=2,p=1$") {
+		t.Fatalf("unexpected PHC string %q", encoded)
+	}
+
+	params, salt, key, err := decodeArgon2id(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *params != *argon {
+		t.Errorf("decoded parameters %+v, want %+v", *params, *argon)
+	}
+	if len(salt) != argon2SaltLength || len(key) != argon2KeyLength {
+		t.Errorf("salt %d bytes, key %d bytes", len(salt), len(key))
+	}
+
+	tests := []struct {
+		password string
+		ok       bool
+	}{
+		{"correct horse battery staple", true},
+		{"correct horse battery stapl", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		ok, err := argon.Verify(tt.password, encoded)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ok != tt.ok {
+			t.Errorf("verify %q = %v, want %v", tt.password, ok, tt.ok)
+		}
+	}
+}
+
+func TestArgon2idRejectsMalformedHashes(t *testing.T) {
+	argon := NewArgon2id(8*1024, 1, 1)
+
+	tests := []struct {
+		name    string
+		encoded string
+	}{
+		{"missing parts", "$argon2id$v=19$m=8192,t=1,p=1$c2FsdA"},
+		{"other version", "$argon2id$v=16$m=8192,t=1,p=1$c2FsdHNhbHRzYWx0c2FsdA$a2V5"},
+		{"bad parameters", "$argon2id$v=19$m=x,t=1,p=1$c2FsdHNhbHRzYWx0c2FsdA$a2V5"},
+		{"bad salt", "$argon2id$v=19$m=8192,t=1,p=1$!!!$a2V5"},
+		{"bad hash", "$argon2id$v=19$m=8192,t=1,p=1$c2FsdHNhbHRzYWx0c2FsdA$!!!"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := argon.Verify("password", tt.encoded); err == nil {
+				t.Error("expected error")
+			}
+			if !argon.NeedsRehash(tt.encoded) {
+				t.Error("malformed hash does not need rehash")
+			}
+		})
+	}
+}
+
+func TestNeedsRehash(t *testing.T) {
+	argon := NewArgon2id(8*1024, 1, 1)
+	argonHash, err := argon.Hash("password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bcryptHash, err := NewBcrypt(bcrypt.MinCost).Hash("password")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		algorithm Algorithm
+		encoded   string
+		want      bool
+	}{
+		{"argon2id same parameters", NewArgon2id(8*1024, 1, 1), argonHash, false},
+		{"argon2id more memory", NewArgon2id(16*1024, 1, 1), argonHash, true},
+		{"argon2id more iterations", NewArgon2id(8*1024, 2, 1), argonHash, true},
+		{"argon2id more parallelism", NewArgon2id(8*1024, 1, 2), argonHash, true},
+		{"bcrypt same cost", NewBcrypt(bcrypt.MinCost), bcryptHash, false},
+		{"bcrypt higher cost", NewBcrypt(bcrypt.MinCost + 1), bcryptHash, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.algorithm.NeedsRehash(tt.encoded); got != tt.want {
+				t.Errorf("NeedsRehash = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHasherUpgradesBcryptToArgon2id(t *testing.T) {
+	legacy, err := testHasher(t, AlgorithmBcrypt).Hash("password")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hasher := testHasher(t, AlgorithmArgon2id)
+
+	ok, rehash, err := hasher.Verify("password", legacy)
+	if err != nil || !ok {
+		t.Fatalf("verify legacy hash: ok %v, err %v", ok, err)
+	}
+	if !rehash {
+		t.Fatal("bcrypt hash not flagged for rehash")
+	}
+
+	// wrong password never asks for a rehash
+	ok, rehash, err = hasher.Verify("wrong", legacy)
+	if err != nil || ok || rehash {
+		t.Fatalf("wrong password: ok %v, rehash %v, err %v", ok, rehash, err)
+	}
+
+	upgraded, err := hasher.Hash("password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(upgraded, "$argon2id$") {
+		t.Fatalf("upgraded hash %q is not argon2id", upgraded)
+	}
+
+	ok, rehash, err = hasher.Verify("password", upgraded)
+	if err != nil || !ok || rehash {
+		t.Fatalf("verify upgraded hash: ok %v, rehash %v, err %v", ok, rehash, err)
+	}
+}
+
+func TestHasherUnknownFormat(t *testing.T) {
+	_, _, err := testHasher(t, "").Verify("password", "$md5$abc")
+	if !errors.Is(err, ErrUnknownHashFormat) {
+		t.Errorf("err = %v, want ErrUnknownHashFormat", err)
+	}
+}
+
+func TestNewHasherRejectsUnknownAlgorithm(t *testing.T) {
+	if _, err := NewHasher(HasherConfig{Algorithm: "scrypt"}); err == nil {
+		t.Error("expected error")
+	}
+}
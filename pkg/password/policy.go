@@ -0,0 +1,114 @@
+package password
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// bcrypt ignores bytes after 72, longer passwords would be silently truncated
+const BcryptMaxBytes = 72
+
+type PolicyConfig struct {
+	MinLength     int
+	MaxBytes      int
+	RequireUpper  bool
+	RequireLower  bool
+	RequireDigit  bool
+	RequireSymbol bool
+
+	// reject password containing the email or its local part
+	DisallowEmail bool
+
+	// directory of SHA-1 prefix files of breached passwords, check is skipped when empty
+	BreachedDir string
+}
+
+type Policy struct {
+	cfg      PolicyConfig
+	breached BreachedChecker
+}
+
+func NewPolicy(cfg PolicyConfig) *Policy {
+	if cfg.MaxBytes <= 0 || cfg.MaxBytes > BcryptMaxBytes {
+		cfg.MaxBytes = BcryptMaxBytes
+	}
+
+	policy := &Policy{cfg: cfg}
+	if cfg.BreachedDir != "" {
+		policy.breached = NewPrefixFileChecker(cfg.BreachedDir)
+	}
+	return policy
+}
+
+// list of violated rules, empty when password is accepted
+func (p *Policy) Validate(password, email string) ([]string, error) {
+
+	var violations []string
+
+	if len([]rune(password)) < p.cfg.MinLength {
+		violations = append(violations, fmt.Sprintf("must be at least %d characters", p.cfg.MinLength))
+	}
+	if len(password) > p.cfg.MaxBytes {
+		violations = append(violations, fmt.Sprintf("must be at most %d bytes", p.cfg.MaxBytes))
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r):
+			hasSymbol = true
+		}
+	}
+	if p.cfg.RequireUpper && !hasUpper {
+		violations = append(violations, "must contain an uppercase letter")
+	}
+	if p.cfg.RequireLower && !hasLower {
+		violations = append(violations, "must contain a lowercase letter")
+	}
+	if p.cfg.RequireDigit && !hasDigit {
+		violations = append(violations, "must contain a digit")
+	}
+	if p.cfg.RequireSymbol && !hasSymbol {
+		violations = append(violations, "must contain a symbol")
+	}
+
+	if p.cfg.DisallowEmail && containsEmail(password, email) {
+		violations = append(violations, "must not contain the email address")
+	}
+
+	// only checked when the password is otherwise valid
+	if len(violations) == 0 && p.breached != nil {
+		breached, err := p.breached.IsBreached(password)
+		if err != nil {
+			return nil, err
+		}
+		if breached {
+			violations = append(violations, "has appeared in a data breach, choose another password")
+		}
+	}
+
+	return violations, nil
+}
+
+func containsEmail(password, email string) bool {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if email == "" {
+		return false
+	}
+
+	password = strings.ToLower(password)
+	if strings.Contains(password, email) {
+		return true
+	}
+
+	// local part shorter than 3 characters would reject too many passwords
+	local, _, _ := strings.Cut(email, "@")
+	return len(local) >= 3 && strings.Contains(password, local)
+}
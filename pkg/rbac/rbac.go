@@ -0,0 +1,45 @@
+package rbac
+
+import (
+	"context"
+	"oapi-to-rest/pkg/db"
+)
+
+// roles assigned to the user in user_roles
+func UserRoles(ctx context.Context, sqlite *db.SQLite, userID any) ([]string, error) {
+	return queryStrings(ctx, sqlite, `
+		SELECT role FROM user_roles
+		WHERE user_id = $1
+		ORDER BY role;
+	`, userID)
+}
+
+// permissions granted by every role of the user
+func UserPermissions(ctx context.Context, sqlite *db.SQLite, userID any) ([]string, error) {
+	return queryStrings(ctx, sqlite, `
+		SELECT DISTINCT rp.permission FROM user_roles ur
+		JOIN role_permissions rp ON rp.role = ur.role
+		WHERE ur.user_id = $1
+		ORDER BY rp.permission;
+	`, userID)
+}
+
+// single text column of every row
+func queryStrings(ctx context.Context, sqlite *db.SQLite, query string, args ...any) ([]string, error) {
+
+	rows, err := sqlite.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	values := []string{}
+	for rows.Next() {
+		var value string
+		if err := rows.Scan(&value); err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, rows.Err()
+}
@@ -0,0 +1,42 @@
+package revocation
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type MemoryStore struct {
+	mu      sync.RWMutex
+	revoked map[string]time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{revoked: map[string]time.Time{}}
+}
+
+func (s *MemoryStore) Revoke(ctx context.Context, jti string, expiresAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// drop entries of tokens that expired meanwhile
+	now := time.Now()
+	for id, exp := range s.revoked {
+		if now.After(exp) {
+			delete(s.revoked, id)
+		}
+	}
+
+	if now.Before(expiresAt) {
+		s.revoked[jti] = expiresAt
+	}
+	return nil
+}
+
+func (s *MemoryStore) IsRevoked(ctx context.Context, jti string) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	exp, ok := s.revoked[jti]
+	return ok && time.Now().Before(exp), nil
+}
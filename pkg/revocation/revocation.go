@@ -0,0 +1,35 @@
+package revocation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"oapi-to-rest/pkg/db"
+	"time"
+)
+
+const (
+	DriverSQLite = "sqlite"
+	DriverMemory = "memory"
+)
+
+// revoked access tokens by jti, an entry is only kept until the token would have expired anyway
+type Store interface {
+	Revoke(ctx context.Context, jti string, expiresAt time.Time) error
+	IsRevoked(ctx context.Context, jti string) (bool, error)
+}
+
+// create store based on configured driver, memory is per process and lost on restart
+func New(driver string, sqlite *db.SQLite) (Store, error) {
+	switch driver {
+	case DriverSQLite, "":
+		if sqlite == nil {
+			return nil, errors.New("sqlite revocation store requires sqlite to be initialized")
+		}
+		return NewSQLiteStore(sqlite), nil
+	case DriverMemory:
+		return NewMemoryStore(), nil
+	default:
+		return nil, fmt.Errorf("unsupported revocation store: %s", driver)
+	}
+}
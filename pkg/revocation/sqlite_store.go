@@ -0,0 +1,56 @@
+package revocation
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"oapi-to-rest/pkg/db"
+	"time"
+)
+
+// revoked_tokens.expires_at is unix seconds so expired entries can be pruned in sql
+type SQLiteStore struct {
+	sqlite *db.SQLite
+}
+
+func NewSQLiteStore(sqlite *db.SQLite) *SQLiteStore {
+	return &SQLiteStore{sqlite: sqlite}
+}
+
+func (s *SQLiteStore) Revoke(ctx context.Context, jti string, expiresAt time.Time) error {
+	now := time.Now()
+
+	// drop entries of tokens that expired meanwhile
+	if _, err := s.sqlite.DB.ExecContext(ctx, `
+		DELETE FROM revoked_tokens WHERE expires_at <= $1;
+	`, now.Unix()); err != nil {
+		return err
+	}
+
+	if !now.Before(expiresAt) {
+		return nil
+	}
+
+	_, err := s.sqlite.DB.ExecContext(ctx, `
+		INSERT INTO revoked_tokens (jti, expires_at)
+		VALUES ($1, $2)
+		ON CONFLICT(jti) DO NOTHING;
+	`, jti, expiresAt.Unix())
+	return err
+}
+
+func (s *SQLiteStore) IsRevoked(ctx context.Context, jti string) (bool, error) {
+	var expiresAt int64
+	err := s.sqlite.DB.QueryRowContext(ctx, `
+		SELECT expires_at FROM revoked_tokens
+		WHERE jti = $1;
+	`, jti).Scan(&expiresAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return time.Now().Unix() < expiresAt, nil
+}
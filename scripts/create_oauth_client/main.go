@@ -0,0 +1,64 @@
+package main
+
+import (
+	"database/sql"
+	"flag"
+	"fmt"
+	"log"
+	"oapi-to-rest/pkg/env"
+	"oapi-to-rest/pkg/helper"
+	"oapi-to-rest/pkg/jwt"
+	"strings"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// register an oauth client allowed to obtain, introspect and revoke tokens, the secret is only printed once
+func main() {
+	dbPath := flag.String("db", "./data/app.db", "path to sqlite db")
+	envPath := flag.String("env", ".env", "path to env file holding TOKEN_HASH_SECRET")
+	name := flag.String("name", "", "client name")
+	scopes := flag.String("scopes", "", "space separated scopes")
+	ttl := flag.Duration("ttl", 0, "lifetime of client_credentials tokens, e.g. 15m, 0 uses JWT_EXPIRES_SECONDS")
+	flag.Parse()
+
+	if *name == "" {
+		log.Fatal("-name is required")
+	}
+
+	cfg, _ := env.LoadConfig(*envPath)
+	hashSecret, err := helper.LoadHMACSecret(cfg.Jwt.TokenHashSecret)
+	if err != nil {
+		log.Fatalf("TOKEN_HASH_SECRET: %v", err)
+	}
+
+	clientID, err := jwt.GenerateOpaqueToken()
+	if err != nil {
+		log.Fatalf("generate client id failed: %v", err)
+	}
+	secret, err := jwt.GenerateOpaqueToken()
+	if err != nil {
+		log.Fatalf("generate client secret failed: %v", err)
+	}
+
+	var ttlSeconds *int64
+	if *ttl > 0 {
+		seconds := int64(ttl.Seconds())
+		ttlSeconds = &seconds
+	}
+
+	db, err := sql.Open("sqlite3", *dbPath)
+	if err != nil {
+		log.Fatalf("open DB: %v", err)
+	}
+	defer db.Close()
+
+	if _, err := db.Exec(`
+		INSERT INTO oauth_clients (client_id, client_secret_hash, name, scopes, token_ttl_seconds)
+		VALUES ($1, $2, $3, $4, $5);
+	`, clientID, jwt.HashToken(hashSecret, secret), *name, strings.Join(strings.Fields(*scopes), " "), ttlSeconds); err != nil {
+		log.Fatalf("insert client failed: %v", err)
+	}
+
+	fmt.Printf("client_id:     %s\nclient_secret: %s\n", clientID, secret)
+}
@@ -4,6 +4,8 @@ CREATE TABLE users (
     first_name TEXT,
     last_name TEXT,
     is_active INTEGER DEFAULT 1,
+    email_verified INTEGER DEFAULT 1, -- 0 while pending verification when REQUIRE_EMAIL_VERIFICATION is on
+    email_verification_sent_at DATETIME,
     created_at DATETIME DEFAULT CURRENT_TIMESTAMP
 );
 
@@ -17,19 +19,210 @@ CREATE TABLE auth_credentials (
     FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE
 );
 
+-- one account per social identity
+CREATE UNIQUE INDEX idx_auth_credentials_provider_id ON auth_credentials(provider, provider_id);
+
 CREATE TABLE user_sessions (
     id TEXT PRIMARY KEY DEFAULT (lower(hex(randomblob(4)) || '-' || hex(randomblob(2)) || '-4' ||
          substr(hex(randomblob(2)), 2) || '-' ||
          substr('89ab', abs(random()) % 4 + 1, 1) ||
          substr(hex(randomblob(2)), 2) || '-' ||
          hex(randomblob(6)))),
+    family_id TEXT, -- refresh token family, shared by sessions rotated from the same login
     user_id TEXT NOT NULL,
-    access_token TEXT,
-    refresh_token TEXT,
+    access_token TEXT, -- HMAC-SHA256 of the token, never plaintext
+    access_token_jti TEXT, -- jti of the access token, revoked when the session is invalidated
+    access_token_expires_at DATETIME,
+    refresh_token TEXT, -- HMAC-SHA256 of the token, never plaintext
     user_agent TEXT,
+    device_class TEXT, -- 'mobile', 'tablet', 'desktop' or 'other', derived from user_agent
     ip_address TEXT,
     is_valid INTEGER DEFAULT 1, -- BOOLEAN as INTEGER
+    impersonator_id TEXT, -- admin acting as the user, NULL for the user's own sessions
     created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
     expires_at DATETIME,
     FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
-);
\ No newline at end of file
+);
+
+CREATE INDEX idx_user_sessions_family_id ON user_sessions(family_id);
+CREATE INDEX idx_user_sessions_refresh_token ON user_sessions(refresh_token);
+
+CREATE TABLE password_reset_tokens (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL,
+    token_hash TEXT NOT NULL UNIQUE, -- HMAC-SHA256 of the token, never plaintext
+    expires_at DATETIME NOT NULL,
+    used_at DATETIME, -- NULL until the token is consumed
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE magic_link_tokens (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL,
+    token_hash TEXT NOT NULL UNIQUE, -- HMAC-SHA256 of the token, never plaintext
+    user_agent_hash TEXT NOT NULL, -- HMAC-SHA256 of the requesting user agent, link only works there
+    expires_at DATETIME NOT NULL,
+    used_at DATETIME, -- NULL until the link is consumed
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE roles (
+    name TEXT PRIMARY KEY, -- 'admin', ...
+    description TEXT NOT NULL DEFAULT '',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE permissions (
+    name TEXT PRIMARY KEY, -- 'resource:action'
+    description TEXT NOT NULL DEFAULT '',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE role_permissions (
+    role TEXT NOT NULL,
+    permission TEXT NOT NULL,
+    PRIMARY KEY (role, permission),
+    FOREIGN KEY (role) REFERENCES roles(name) ON DELETE CASCADE,
+    FOREIGN KEY (permission) REFERENCES permissions(name) ON DELETE CASCADE
+);
+
+CREATE TABLE user_roles (
+    user_id INTEGER NOT NULL,
+    role TEXT NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, role),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (role) REFERENCES roles(name) ON DELETE CASCADE
+);
+
+INSERT INTO roles (name, description) VALUES ('admin', 'manages users and their roles');
+
+INSERT INTO permissions (name, description) VALUES
+    ('roles:read', 'list roles and role assignments'),
+    ('roles:manage', 'grant and revoke roles of users'),
+    ('users:read', 'list every user, not only the caller');
+
+INSERT INTO role_permissions (role, permission) VALUES
+    ('admin', 'roles:read'),
+    ('admin', 'roles:manage'),
+    ('admin', 'users:read');
+
+CREATE TABLE user_mfa_totp (
+    user_id INTEGER PRIMARY KEY,
+    secret_encrypted TEXT NOT NULL, -- AES-256-GCM with MFA_ENCRYPTION_KEY
+    confirmed_at DATETIME, -- NULL while enrollment is pending
+    last_used_step INTEGER, -- time step of last accepted code, prevents replay
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE mfa_recovery_codes (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL,
+    code_hash TEXT NOT NULL, -- HMAC-SHA256 of the code
+    used_at DATETIME,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_mfa_recovery_codes_user_id ON mfa_recovery_codes(user_id);
+
+CREATE TABLE mfa_challenges (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL,
+    token_hash TEXT NOT NULL UNIQUE, -- HMAC-SHA256 of the mfa token
+    attempts INTEGER DEFAULT 0,
+    expires_at DATETIME NOT NULL,
+    used_at DATETIME,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE login_throttles (
+    key TEXT PRIMARY KEY, -- 'account:<email>' or 'ip:<address>'
+    failures INTEGER NOT NULL DEFAULT 0,
+    last_failed_at DATETIME,
+    locked_until DATETIME
+);
+
+CREATE TABLE oauth_states (
+    state_hash TEXT PRIMARY KEY, -- HMAC-SHA256 of the state parameter
+    provider TEXT NOT NULL,
+    code_verifier TEXT NOT NULL, -- PKCE verifier sent with the token exchange
+    nonce TEXT NOT NULL, -- expected in the id_token of OIDC providers
+    expires_at DATETIME NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE api_keys (
+    id TEXT PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    name TEXT NOT NULL,
+    prefix TEXT NOT NULL, -- first characters of the key, shown to recognize it
+    key_hash TEXT NOT NULL UNIQUE, -- HMAC-SHA256 of the key, never plaintext
+    scopes TEXT NOT NULL DEFAULT '', -- space separated
+    last_used_at DATETIME,
+    expires_at DATETIME, -- NULL never expires
+    revoked_at DATETIME,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_api_keys_user_id ON api_keys(user_id);
+
+CREATE TABLE audit_log (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    event TEXT NOT NULL, -- 'impersonation.start', ...
+    actor_user_id INTEGER NOT NULL,
+    target_user_id INTEGER,
+    session_id TEXT,
+    reason TEXT,
+    ip_address TEXT,
+    user_agent TEXT,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_audit_log_target_user_id ON audit_log(target_user_id);
+
+CREATE TABLE revoked_tokens (
+    jti TEXT PRIMARY KEY,
+    expires_at INTEGER NOT NULL, -- unix seconds of token expiry, entry is pruned afterwards
+    revoked_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+-- clients allowed to obtain, introspect and revoke tokens
+CREATE TABLE oauth_clients (
+    client_id TEXT PRIMARY KEY,
+    client_secret_hash TEXT NOT NULL, -- HMAC-SHA256 of the secret, never plaintext
+    name TEXT NOT NULL,
+    scopes TEXT NOT NULL DEFAULT '', -- space separated
+    token_ttl_seconds INTEGER, -- lifetime of client_credentials tokens, NULL uses JWT_EXPIRES_SECONDS
+    is_active INTEGER DEFAULT 1,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+-- one-shot migrations already reflected by this schema
+CREATE TABLE schema_migrations (
+    name TEXT PRIMARY KEY,
+    applied_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO schema_migrations (name) VALUES
+    ('hash_session_tokens'),
+    ('session_token_family'),
+    ('password_reset_tokens'),
+    ('email_verification'),
+    ('mfa'),
+    ('login_throttles'),
+    ('oauth_provider_identity'),
+    ('api_keys'),
+    ('magic_link_tokens'),
+    ('session_impersonation'),
+    ('session_device_class'),
+    ('token_revocation'),
+    ('oauth_clients'),
+    ('roles_and_permissions'),
+    ('users_read_permission'),
+    ('oauth_state_nonce');
\ No newline at end of file
@@ -0,0 +1,369 @@
+package main
+
+import (
+	"database/sql"
+	"flag"
+	"fmt"
+	"log"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// schema change of databases created before it was added to default_sqlite_ddl.sql,
+// columns already present are skipped so databases created in between can be migrated too
+type migration struct {
+	name       string
+	columns    []column
+	statements []string
+}
+
+type column struct {
+	table      string
+	name       string
+	definition string
+}
+
+// applied in order, each at most once
+var migrations = []migration{
+	{
+		name: "session_token_family",
+		columns: []column{
+			{"user_sessions", "family_id", "TEXT"},
+		},
+		statements: []string{
+			`CREATE INDEX IF NOT EXISTS idx_user_sessions_family_id ON user_sessions(family_id);`,
+		},
+	},
+	{
+		name: "password_reset_tokens",
+		statements: []string{
+			`
+				CREATE TABLE IF NOT EXISTS password_reset_tokens (
+					id INTEGER PRIMARY KEY AUTOINCREMENT,
+					user_id INTEGER NOT NULL,
+					token_hash TEXT NOT NULL UNIQUE, -- HMAC-SHA256 of the token, never plaintext
+					expires_at DATETIME NOT NULL,
+					used_at DATETIME, -- NULL until the token is consumed
+					created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+					FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+				);
+			`,
+		},
+	},
+	{
+		name: "email_verification",
+		columns: []column{
+			{"users", "email_verified", "INTEGER DEFAULT 1"},
+			{"users", "email_verification_sent_at", "DATETIME"},
+		},
+	},
+	{
+		name: "mfa",
+		statements: []string{
+			`
+				CREATE TABLE IF NOT EXISTS user_mfa_totp (
+					user_id INTEGER PRIMARY KEY,
+					secret_encrypted TEXT NOT NULL, -- AES-256-GCM with MFA_ENCRYPTION_KEY
+					confirmed_at DATETIME, -- NULL while enrollment is pending
+					last_used_step INTEGER, -- time step of last accepted code, prevents replay
+					created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+					FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+				);
+			`,
+			`
+				CREATE TABLE IF NOT EXISTS mfa_recovery_codes (
+					id INTEGER PRIMARY KEY AUTOINCREMENT,
+					user_id INTEGER NOT NULL,
+					code_hash TEXT NOT NULL, -- HMAC-SHA256 of the code
+					used_at DATETIME,
+					created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+					FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+				);
+			`,
+			`CREATE INDEX IF NOT EXISTS idx_mfa_recovery_codes_user_id ON mfa_recovery_codes(user_id);`,
+			`
+				CREATE TABLE IF NOT EXISTS mfa_challenges (
+					id INTEGER PRIMARY KEY AUTOINCREMENT,
+					user_id INTEGER NOT NULL,
+					token_hash TEXT NOT NULL UNIQUE, -- HMAC-SHA256 of the mfa token
+					attempts INTEGER DEFAULT 0,
+					expires_at DATETIME NOT NULL,
+					used_at DATETIME,
+					created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+					FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+				);
+			`,
+		},
+	},
+	{
+		name: "login_throttles",
+		statements: []string{
+			`
+				CREATE TABLE IF NOT EXISTS login_throttles (
+					key TEXT PRIMARY KEY, -- 'account:<email>' or 'ip:<address>'
+					failures INTEGER NOT NULL DEFAULT 0,
+					last_failed_at DATETIME,
+					locked_until DATETIME
+				);
+			`,
+		},
+	},
+	{
+		name: "oauth_provider_identity",
+		statements: []string{
+			// an identity linked to several users stays with the user it was linked to first
+			`
+				DELETE FROM auth_credentials
+				WHERE provider_id IS NOT NULL AND id NOT IN (
+					SELECT MIN(id) FROM auth_credentials
+					WHERE provider_id IS NOT NULL
+					GROUP BY provider, provider_id
+				);
+			`,
+			`CREATE UNIQUE INDEX IF NOT EXISTS idx_auth_credentials_provider_id ON auth_credentials(provider, provider_id);`,
+		},
+	},
+	{
+		name: "api_keys",
+		statements: []string{
+			`
+				CREATE TABLE IF NOT EXISTS api_keys (
+					id TEXT PRIMARY KEY,
+					user_id INTEGER NOT NULL,
+					name TEXT NOT NULL,
+					prefix TEXT NOT NULL, -- first characters of the key, shown to recognize it
+					key_hash TEXT NOT NULL UNIQUE, -- HMAC-SHA256 of the key, never plaintext
+					scopes TEXT NOT NULL DEFAULT '', -- space separated
+					last_used_at DATETIME,
+					expires_at DATETIME, -- NULL never expires
+					revoked_at DATETIME,
+					created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+					FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+				);
+			`,
+			`CREATE INDEX IF NOT EXISTS idx_api_keys_user_id ON api_keys(user_id);`,
+		},
+	},
+	{
+		name: "magic_link_tokens",
+		statements: []string{
+			`
+				CREATE TABLE IF NOT EXISTS magic_link_tokens (
+					id INTEGER PRIMARY KEY AUTOINCREMENT,
+					user_id INTEGER NOT NULL,
+					token_hash TEXT NOT NULL UNIQUE, -- HMAC-SHA256 of the token, never plaintext
+					user_agent_hash TEXT NOT NULL, -- HMAC-SHA256 of the requesting user agent, link only works there
+					expires_at DATETIME NOT NULL,
+					used_at DATETIME, -- NULL until the link is consumed
+					created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+					FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+				);
+			`,
+		},
+	},
+	{
+		name: "session_impersonation",
+		columns: []column{
+			{"user_sessions", "impersonator_id", "TEXT"},
+		},
+		statements: []string{
+			`
+				CREATE TABLE IF NOT EXISTS audit_log (
+					id INTEGER PRIMARY KEY AUTOINCREMENT,
+					event TEXT NOT NULL, -- 'impersonation.start', ...
+					actor_user_id INTEGER NOT NULL,
+					target_user_id INTEGER,
+					session_id TEXT,
+					reason TEXT,
+					ip_address TEXT,
+					user_agent TEXT,
+					created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+				);
+			`,
+			`CREATE INDEX IF NOT EXISTS idx_audit_log_target_user_id ON audit_log(target_user_id);`,
+		},
+	},
+	{
+		name: "session_device_class",
+		columns: []column{
+			{"user_sessions", "device_class", "TEXT"},
+		},
+	},
+	{
+		name: "token_revocation",
+		columns: []column{
+			{"user_sessions", "access_token_jti", "TEXT"},
+			{"user_sessions", "access_token_expires_at", "DATETIME"},
+		},
+		statements: []string{
+			`
+				CREATE TABLE IF NOT EXISTS revoked_tokens (
+					jti TEXT PRIMARY KEY,
+					expires_at INTEGER NOT NULL, -- unix seconds of token expiry, entry is pruned afterwards
+					revoked_at DATETIME DEFAULT CURRENT_TIMESTAMP
+				);
+			`,
+		},
+	},
+	{
+		name: "oauth_clients",
+		statements: []string{
+			`
+				CREATE TABLE IF NOT EXISTS oauth_clients (
+					client_id TEXT PRIMARY KEY,
+					client_secret_hash TEXT NOT NULL, -- HMAC-SHA256 of the secret, never plaintext
+					name TEXT NOT NULL,
+					scopes TEXT NOT NULL DEFAULT '', -- space separated
+					token_ttl_seconds INTEGER, -- lifetime of client_credentials tokens, NULL uses JWT_EXPIRES_SECONDS
+					is_active INTEGER DEFAULT 1,
+					created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+				);
+			`,
+		},
+	},
+	{
+		name: "roles_and_permissions",
+		statements: []string{
+			`
+				CREATE TABLE IF NOT EXISTS roles (
+					name TEXT PRIMARY KEY, -- 'admin', ...
+					description TEXT NOT NULL DEFAULT '',
+					created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+				);
+			`,
+			`
+				CREATE TABLE IF NOT EXISTS permissions (
+					name TEXT PRIMARY KEY, -- 'resource:action'
+					description TEXT NOT NULL DEFAULT '',
+					created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+				);
+			`,
+			`
+				CREATE TABLE IF NOT EXISTS role_permissions (
+					role TEXT NOT NULL,
+					permission TEXT NOT NULL,
+					PRIMARY KEY (role, permission),
+					FOREIGN KEY (role) REFERENCES roles(name) ON DELETE CASCADE,
+					FOREIGN KEY (permission) REFERENCES permissions(name) ON DELETE CASCADE
+				);
+			`,
+			`
+				CREATE TABLE IF NOT EXISTS user_roles (
+					user_id INTEGER NOT NULL,
+					role TEXT NOT NULL,
+					created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+					PRIMARY KEY (user_id, role),
+					FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+					FOREIGN KEY (role) REFERENCES roles(name) ON DELETE CASCADE
+				);
+			`,
+			`INSERT OR IGNORE INTO roles (name, description) VALUES ('admin', 'manages users and their roles');`,
+			`INSERT OR IGNORE INTO permissions (name, description) VALUES ('roles:read', 'list roles and role assignments'), ('roles:manage', 'grant and revoke roles of users');`,
+			`INSERT OR IGNORE INTO role_permissions (role, permission) VALUES ('admin', 'roles:read'), ('admin', 'roles:manage');`,
+		},
+	},
+	{
+		name: "users_read_permission",
+		statements: []string{
+			`INSERT OR IGNORE INTO permissions (name, description) VALUES ('users:read', 'list every user, not only the caller');`,
+			`INSERT OR IGNORE INTO role_permissions (role, permission) SELECT name, 'users:read' FROM roles WHERE name = 'admin';`,
+		},
+	},
+	// states only live minutes, logins in flight have to start again
+	{
+		name: "oauth_state_nonce",
+		statements: []string{
+			`DROP TABLE IF EXISTS oauth_states;`,
+			`
+				CREATE TABLE oauth_states (
+					state_hash TEXT PRIMARY KEY, -- HMAC-SHA256 of the state parameter
+					provider TEXT NOT NULL,
+					code_verifier TEXT NOT NULL, -- PKCE verifier sent with the token exchange
+					nonce TEXT NOT NULL, -- expected in the id_token of OIDC providers
+					expires_at DATETIME NOT NULL,
+					created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+				);
+			`,
+		},
+	},
+}
+
+func main() {
+	dbPath := flag.String("db", "./data/app.db", "path to sqlite db")
+	flag.Parse()
+
+	db, err := sql.Open("sqlite3", *dbPath)
+	if err != nil {
+		log.Fatalf("open DB: %v", err)
+	}
+	defer db.Close()
+
+	if _, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			name TEXT PRIMARY KEY,
+			applied_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		);
+	`); err != nil {
+		log.Fatalf("create schema_migrations failed: %v", err)
+	}
+
+	for _, m := range migrations {
+		if err := apply(db, m); err != nil {
+			log.Fatalf("migration %s failed: %v", m.name, err)
+		}
+	}
+}
+
+func apply(db *sql.DB, m migration) error {
+
+	var applied int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM schema_migrations WHERE name = $1;`, m.name).Scan(&applied); err != nil {
+		return err
+	}
+	if applied > 0 {
+		log.Printf("migration %s already applied, skipping.", m.name)
+		return nil
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, c := range m.columns {
+		exists, err := hasColumn(tx, c.table, c.name)
+		if err != nil {
+			return err
+		}
+		if exists {
+			continue
+		}
+		if _, err := tx.Exec(fmt.Sprintf(`ALTER TABLE %s ADD COLUMN %s %s;`, c.table, c.name, c.definition)); err != nil {
+			return err
+		}
+	}
+
+	for _, statement := range m.statements {
+		if _, err := tx.Exec(statement); err != nil {
+			return err
+		}
+	}
+
+	if _, err := tx.Exec(`INSERT INTO schema_migrations (name) VALUES ($1);`, m.name); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	log.Printf("migration %s applied.", m.name)
+	return nil
+}
+
+func hasColumn(tx *sql.Tx, table, name string) (bool, error) {
+	var count int
+	err := tx.QueryRow(`SELECT COUNT(*) FROM pragma_table_info($1) WHERE name = $2;`, table, name).Scan(&count)
+	return count > 0, err
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"database/sql"
+	"flag"
+	"log"
+	"oapi-to-rest/pkg/env"
+	"oapi-to-rest/pkg/helper"
+	"oapi-to-rest/pkg/jwt"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// one-shot migration, hash plaintext tokens of existing user_sessions rows
+const migrationName = "hash_session_tokens"
+
+func main() {
+	dbPath := flag.String("db", "./data/app.db", "path to sqlite db")
+	envPath := flag.String("env", ".env", "path to env file holding TOKEN_HASH_SECRET")
+	flag.Parse()
+
+	cfg, _ := env.LoadConfig(*envPath)
+	secret, err := helper.LoadHMACSecret(cfg.Jwt.TokenHashSecret)
+	if err != nil {
+		log.Fatalf("TOKEN_HASH_SECRET: %v", err)
+	}
+
+	db, err := sql.Open("sqlite3", *dbPath)
+	if err != nil {
+		log.Fatalf("open DB: %v", err)
+	}
+	defer db.Close()
+
+	if _, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			name TEXT PRIMARY KEY,
+			applied_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		);
+	`); err != nil {
+		log.Fatalf("create schema_migrations failed: %v", err)
+	}
+
+	// hashing twice would invalidate every session, so never run again
+	var applied int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM schema_migrations WHERE name = $1;`, migrationName).Scan(&applied); err != nil {
+		log.Fatalf("check migration failed: %v", err)
+	}
+	if applied > 0 {
+		log.Printf("migration %s already applied, skipping.", migrationName)
+		return
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		log.Fatalf("begin transaction failed: %v", err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.Query(`SELECT id, access_token, refresh_token FROM user_sessions;`)
+	if err != nil {
+		log.Fatalf("select sessions failed: %v", err)
+	}
+
+	type session struct {
+		id           string
+		accessToken  sql.NullString
+		refreshToken sql.NullString
+	}
+
+	var sessions []session
+	for rows.Next() {
+		var s session
+		if err := rows.Scan(&s.id, &s.accessToken, &s.refreshToken); err != nil {
+			log.Fatalf("scan session failed: %v", err)
+		}
+		sessions = append(sessions, s)
+	}
+	if err := rows.Err(); err != nil {
+		log.Fatalf("iterate sessions failed: %v", err)
+	}
+	rows.Close()
+
+	for _, s := range sessions {
+		accessToken, refreshToken := s.accessToken, s.refreshToken
+		if accessToken.Valid {
+			accessToken.String = jwt.HashToken(secret, accessToken.String)
+		}
+		if refreshToken.Valid {
+			refreshToken.String = jwt.HashToken(secret, refreshToken.String)
+		}
+
+		if _, err := tx.Exec(`
+			UPDATE user_sessions
+			SET access_token = $1, refresh_token = $2
+			WHERE id = $3;
+		`, accessToken, refreshToken, s.id); err != nil {
+			log.Fatalf("hash tokens of session %s failed: %v", s.id, err)
+		}
+	}
+
+	if _, err := tx.Exec(`CREATE INDEX IF NOT EXISTS idx_user_sessions_refresh_token ON user_sessions(refresh_token);`); err != nil {
+		log.Fatalf("create refresh token index failed: %v", err)
+	}
+
+	if _, err := tx.Exec(`INSERT INTO schema_migrations (name) VALUES ($1);`, migrationName); err != nil {
+		log.Fatalf("record migration failed: %v", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Fatalf("commit migration failed: %v", err)
+	}
+
+	log.Printf("migration %s applied, %d sessions hashed.", migrationName, len(sessions))
+}